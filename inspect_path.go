@@ -14,9 +14,12 @@ const (
 	Size     = 400
 	Border   = 10
 	PathStep = 0.01
+
+	// FlattenTolerance is the maximum error of the drawing, in pixels.
+	FlattenTolerance = 0.25
 )
 
-var Segments []svg.PathSegment
+var Subpaths []svg.Subpath
 var Bounds svg.Rect
 var Selected svg.PathSegment
 
@@ -55,13 +58,15 @@ func updateSelected(e gogui.MouseEvent) {
 	Selected = nil
 
 SegmentLoop:
-	for _, segment := range Segments {
-		for t := 0.0; t <= 1; t += PathStep {
-			point := segment.Evaluate(t)
-			screenPoint := svg.Point{point.X*scale + translateX, point.Y*scale + translateY}
-			if (svg.Line{mousePoint, screenPoint}).Length() < 5 {
-				Selected = segment
-				break SegmentLoop
+	for _, subpath := range Subpaths {
+		for _, segment := range subpath.Segments {
+			for t := 0.0; t <= 1; t += PathStep {
+				point := segment.Evaluate(t)
+				screenPoint := svg.Point{point.X*scale + translateX, point.Y*scale + translateY}
+				if (svg.Line{mousePoint, screenPoint}).Length() < 5 {
+					Selected = segment
+					break SegmentLoop
+				}
 			}
 		}
 	}
@@ -71,18 +76,17 @@ func tracePath(ctx gogui.DrawContext) {
 	translateX, translateY, scale := transformation()
 	ctx.SetStroke(gogui.Color{0, 0, 0, 1})
 	ctx.SetThickness(1)
-	for i, segment := range Segments {
-		if segment == Selected {
-			ctx.MoveTo(segment.To().X*scale+translateX, segment.To().Y*scale+translateY)
-			continue
-		}
-		if i == 0 {
-			startPoint := segment.From()
-			ctx.MoveTo(startPoint.X*scale+translateX, startPoint.Y*scale+translateY)
-		}
-		for t := PathStep; t < 1; t += PathStep {
-			point := segment.Evaluate(t)
-			ctx.LineTo(point.X*scale+translateX, point.Y*scale+translateY)
+	for _, subpath := range Subpaths {
+		startPoint := subpath.Segments[0].From()
+		ctx.MoveTo(startPoint.X*scale+translateX, startPoint.Y*scale+translateY)
+		for _, segment := range subpath.Segments {
+			if segment == Selected {
+				ctx.MoveTo(segment.To().X*scale+translateX, segment.To().Y*scale+translateY)
+				continue
+			}
+			for _, point := range segment.Flatten(FlattenTolerance / scale)[1:] {
+				ctx.LineTo(point.X*scale+translateX, point.Y*scale+translateY)
+			}
 		}
 	}
 	ctx.StrokePath()
@@ -90,8 +94,7 @@ func tracePath(ctx gogui.DrawContext) {
 		ctx.SetStroke(gogui.Color{0, 1, 0, 1})
 		ctx.SetThickness(4)
 		ctx.MoveTo(Selected.From().X*scale+translateX, Selected.From().Y*scale+translateY)
-		for t := PathStep; t <= 1; t += PathStep {
-			point := Selected.Evaluate(t)
+		for _, point := range Selected.Flatten(FlattenTolerance / scale)[1:] {
 			ctx.LineTo(point.X*scale+translateX, point.Y*scale+translateY)
 		}
 		ctx.StrokePath()
@@ -119,22 +122,24 @@ func readPathAndBounds() error {
 	if err != nil {
 		return err
 	}
-	path, err := svg.ParsePath(string(data))
-	if err != nil {
-		return err
+	path, errs := svg.ParsePathLenient(string(data))
+	for _, err := range errs {
+		fmt.Fprintln(os.Stderr, "Path error:", err)
 	}
 
-	Segments = path.Segments()
+	Subpaths = path.Subpaths()
 
-	if len(Segments) == 0 {
+	if len(Subpaths) == 0 {
 		return nil
 	}
 
-	Bounds = Segments[0].Bounds()
-	for _, segment := range Segments {
-		b := segment.Bounds()
-		Bounds.Min = svg.Point{math.Min(Bounds.Min.X, b.Min.X), math.Min(Bounds.Min.Y, b.Min.Y)}
-		Bounds.Max = svg.Point{math.Max(Bounds.Max.X, b.Max.X), math.Max(Bounds.Max.Y, b.Max.Y)}
+	Bounds = Subpaths[0].Segments[0].Bounds()
+	for _, subpath := range Subpaths {
+		for _, segment := range subpath.Segments {
+			b := segment.Bounds()
+			Bounds.Min = svg.Point{math.Min(Bounds.Min.X, b.Min.X), math.Min(Bounds.Min.Y, b.Min.Y)}
+			Bounds.Max = svg.Point{math.Max(Bounds.Max.X, b.Max.X), math.Max(Bounds.Max.Y, b.Max.Y)}
+		}
 	}
 	return nil
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -9,6 +10,9 @@ import (
 )
 
 func main() {
+	tolerance := flag.Float64("tolerance", 1e-6, "maximum error in the reported length")
+	flag.Parse()
+
 	fmt.Println("Enter path data, then deliver an EOF:")
 	data, err := ioutil.ReadAll(os.Stdin)
 	if err != nil {
@@ -20,9 +24,10 @@ func main() {
 		fmt.Fprintln(os.Stderr, "Failed to parse:", err)
 		os.Exit(1)
 	}
+	segments := path.Segments()
 	var length float64
-	for _, segment := range path.Segments() {
-		length += segment.Length()
+	for _, segment := range segments {
+		length += segment.LengthWithTolerance(*tolerance / float64(len(segments)))
 	}
-	fmt.Println("length is approximately", length, "units")
+	fmt.Println("length is", length, "units, within", *tolerance)
 }
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"math"
+	"os"
+
+	"github.com/unixpickle/svgdemos/svg"
+)
+
+const Border = 10
+
+func main() {
+	size := flag.Int("size", 400, "width and height of the image")
+	output := flag.String("out", "path.png", "output PNG file")
+	evenOdd := flag.Bool("evenodd", false, "use the evenodd fill rule")
+	flag.Parse()
+
+	fmt.Println("Enter path data, then deliver an EOF:")
+	data, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to read data:", err)
+		os.Exit(1)
+	}
+	path, errs := svg.ParsePathLenient(string(data))
+	for _, err := range errs {
+		fmt.Fprintln(os.Stderr, "Path error:", err)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, *size, *size))
+	rule := svg.NonZero
+	if *evenOdd {
+		rule = svg.EvenOdd
+	}
+	svg.FillPath(img, path.Transform(fitTransform(path, float64(*size))), color.Black, rule)
+
+	f, err := os.Create(*output)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to create output:", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		fmt.Fprintln(os.Stderr, "Failed to write image:", err)
+		os.Exit(1)
+	}
+}
+
+// fitTransform scales and centers a path so that it fills the image.
+func fitTransform(path svg.Path, size float64) svg.Matrix {
+	segments := path.Segments()
+	if len(segments) == 0 {
+		return svg.IdentityMatrix()
+	}
+	bounds := segments[0].Bounds()
+	for _, segment := range segments {
+		b := segment.Bounds()
+		bounds.Min = svg.Point{math.Min(bounds.Min.X, b.Min.X), math.Min(bounds.Min.Y, b.Min.Y)}
+		bounds.Max = svg.Point{math.Max(bounds.Max.X, b.Max.X), math.Max(bounds.Max.Y, b.Max.Y)}
+	}
+	extent := math.Max(bounds.Width(), bounds.Height())
+	if extent == 0 {
+		return svg.IdentityMatrix()
+	}
+	scale := (size - Border*2) / extent
+	translateX := (size-bounds.Width()*scale)/2 - bounds.Min.X*scale
+	translateY := (size-bounds.Height()*scale)/2 - bounds.Min.Y*scale
+	return svg.TranslateMatrix(translateX, translateY).Multiply(svg.ScaleMatrix(scale, scale))
+}
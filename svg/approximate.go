@@ -0,0 +1,200 @@
+package svg
+
+import "math"
+
+// maxApproximationPieces limits the number of curves used to approximate a
+// single segment.
+const maxApproximationPieces = 1024
+
+// Cubics approximates the arc with cubic Bezier curves, which are within the
+// tolerance of the arc. The arc is split into pieces of at most 90 degrees,
+// or more if the tolerance calls for it, and each piece is replaced by the
+// usual cubic whose control points lie on the tangents at its ends.
+func (a *ArcParams) Cubics(tolerance float64) []*CubicBezier {
+	delta := a.angleDelta()
+	radius := math.Max(a.XRadius, a.YRadius)
+
+	// On a circle of radius r, the cubic for an angle of theta is at most
+	// r*2*sin(theta/4)^6/(27*cos(theta/4)^2) away from the arc. An ellipse is
+	// the image of a circle under a map which scales by at most its largest
+	// radius.
+	pieces := int(math.Max(1, math.Ceil(math.Abs(delta)/90)))
+	for ; pieces < maxApproximationPieces; pieces++ {
+		sin, cos := math.Sincos(math.Abs(delta) / float64(pieces) * math.Pi / 180 / 4)
+		if radius*2*math.Pow(sin, 6)/(27*cos*cos) <= tolerance {
+			break
+		}
+	}
+
+	step := delta / float64(pieces)
+	k := 4.0 / 3 * math.Tan(step*math.Pi/180/4)
+	res := make([]*CubicBezier, pieces)
+	for i := range res {
+		angle1 := a.StartAngle + step*float64(i)
+		angle2 := angle1 + step
+		start, end := a.evaluateAngle(angle1), a.evaluateAngle(angle2)
+		d1, d2 := a.angleDerivative(angle1), a.angleDerivative(angle2)
+		res[i] = &CubicBezier{
+			Start:    start,
+			Control1: Point{start.X + k*d1.X, start.Y + k*d1.Y},
+			Control2: Point{end.X - k*d2.X, end.Y - k*d2.Y},
+			End:      end,
+		}
+	}
+	return res
+}
+
+// Cubics approximates the arc with cubic Bezier curves, which are within the
+// tolerance of the arc. The first curve starts exactly at a.Start and the
+// last one ends exactly at a.End. An arc which is drawn as a line is turned
+// into a single straight curve, and an arc which ends where it starts, and so
+// is not drawn, gives no curves at all.
+func (a *Arc) Cubics(tolerance float64) []*CubicBezier {
+	if a.Start == a.End {
+		return []*CubicBezier{}
+	}
+	params, line := a.Params()
+	if line != nil {
+		return []*CubicBezier{{line.Start, line.Evaluate(1.0 / 3), line.Evaluate(2.0 / 3),
+			line.End}}
+	}
+	res := params.Cubics(tolerance)
+	res[0].Start = a.Start
+	res[len(res)-1].End = a.End
+	return res
+}
+
+// ArcsToCubics replaces every arc in the path with cubic Bezier curves which
+// are within the tolerance of it, using Arc.Cubics. Arcs which are drawn as
+// lines become lines. The result is a normalized path. A path must be
+// validated before its arcs can be replaced.
+func (p Path) ArcsToCubics(tolerance float64) Path {
+	normalized := p.Normalize()
+	res := make(Path, 0, len(normalized))
+	currentPoint := Point{0, 0}
+	subpathStart := Point{0, 0}
+	for _, cmd := range normalized {
+		switch cmd.Name {
+		case "M":
+			subpathStart = Point{cmd.Args[0], cmd.Args[1]}
+		case "A":
+			end := Point{cmd.Args[5], cmd.Args[6]}
+			arc := &Arc{currentPoint, end, cmd.Args[0], cmd.Args[1], cmd.Args[2],
+				cmd.Args[3] != 0, cmd.Args[4] != 0}
+			if _, line := arc.Params(); line != nil {
+				res = append(res, PathCmd{"L", []float64{end.X, end.Y}})
+			} else {
+				for _, cubic := range arc.Cubics(tolerance) {
+					res = append(res, segmentCommand(cubic))
+				}
+			}
+			currentPoint = end
+			continue
+		}
+		res = append(res, cmd.Clone())
+		if cmd.Name == "Z" {
+			currentPoint = subpathStart
+		} else {
+			currentPoint = Point{cmd.Args[len(cmd.Args)-2], cmd.Args[len(cmd.Args)-1]}
+		}
+	}
+	return res
+}
+
+// ToCubic converts the curve into a cubic Bezier curve with the same shape.
+func (q *QuadraticBezier) ToCubic() *CubicBezier {
+	return &CubicBezier{
+		Start:    q.Start,
+		Control1: Line{q.Start, q.Control}.Evaluate(2.0 / 3),
+		Control2: Line{q.End, q.Control}.Evaluate(2.0 / 3),
+		End:      q.End,
+	}
+}
+
+// ToQuadratics approximates the curve with quadratic Bezier curves, which are
+// within the tolerance of the curve. The curve is split evenly into as few
+// pieces as the tolerance allows, and each piece is replaced by its closest
+// quadratic curve. The first curve starts exactly at c.Start and the last one
+// ends exactly at c.End.
+func (c *CubicBezier) ToQuadratics(tolerance float64) []*QuadraticBezier {
+	// Splitting a curve into n pieces divides the error of each piece's
+	// approximation by n^3.
+	_, maxError := c.quadraticApproximation()
+	pieces := maxApproximationPieces
+	if tolerance > 0 {
+		pieces = int(math.Min(float64(pieces), math.Max(1, math.Ceil(math.Cbrt(maxError/tolerance)))))
+	}
+
+	res := make([]*QuadraticBezier, pieces)
+	start := c.Start
+	for i := range res {
+		t0, t1 := float64(i)/float64(pieces), float64(i+1)/float64(pieces)
+		end := c.End
+		if i+1 < pieces {
+			end = c.Evaluate(t1)
+		}
+		control, _ := c.Subsegment(t0, t1).quadraticApproximation()
+		res[i] = &QuadraticBezier{start, control, end}
+		start = end
+	}
+	return res
+}
+
+// QuadraticsToCubics replaces every quadratic Bezier curve in the path with
+// the equivalent cubic Bezier curve. The result is a normalized path. A path
+// must be validated before its curves can be replaced.
+func (p Path) QuadraticsToCubics() Path {
+	normalized := p.Normalize()
+	res := make(Path, len(normalized))
+	currentPoint := Point{0, 0}
+	subpathStart := Point{0, 0}
+	for i, cmd := range normalized {
+		res[i] = cmd.Clone()
+		switch cmd.Name {
+		case "M":
+			subpathStart = Point{cmd.Args[0], cmd.Args[1]}
+		case "Q":
+			q := &QuadraticBezier{currentPoint, Point{cmd.Args[0], cmd.Args[1]},
+				Point{cmd.Args[2], cmd.Args[3]}}
+			res[i] = segmentCommand(q.ToCubic())
+		}
+		if cmd.Name == "Z" {
+			currentPoint = subpathStart
+		} else {
+			currentPoint = Point{cmd.Args[len(cmd.Args)-2], cmd.Args[len(cmd.Args)-1]}
+		}
+	}
+	return res
+}
+
+// CubicsToQuadratics replaces every cubic Bezier curve in the path with
+// quadratic Bezier curves which are within the tolerance of it, using
+// CubicBezier.ToQuadratics. The result is a normalized path. A path must be
+// validated before its curves can be replaced.
+func (p Path) CubicsToQuadratics(tolerance float64) Path {
+	normalized := p.Normalize()
+	res := make(Path, 0, len(normalized))
+	currentPoint := Point{0, 0}
+	subpathStart := Point{0, 0}
+	for _, cmd := range normalized {
+		switch cmd.Name {
+		case "M":
+			subpathStart = Point{cmd.Args[0], cmd.Args[1]}
+			res = append(res, cmd.Clone())
+		case "C":
+			c := &CubicBezier{currentPoint, Point{cmd.Args[0], cmd.Args[1]},
+				Point{cmd.Args[2], cmd.Args[3]}, Point{cmd.Args[4], cmd.Args[5]}}
+			for _, q := range c.ToQuadratics(tolerance) {
+				res = append(res, segmentCommand(q))
+			}
+		default:
+			res = append(res, cmd.Clone())
+		}
+		if cmd.Name == "Z" {
+			currentPoint = subpathStart
+		} else {
+			currentPoint = Point{cmd.Args[len(cmd.Args)-2], cmd.Args[len(cmd.Args)-1]}
+		}
+	}
+	return res
+}
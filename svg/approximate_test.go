@@ -0,0 +1,160 @@
+package svg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestArcCubics(t *testing.T) {
+	arcs := []*Arc{
+		{Point{10, 0}, Point{0, 10}, 10, 10, 0, false, true},
+		{Point{10, 0}, Point{0, 10}, 10, 10, 0, true, false},
+		{Point{10, 0}, Point{0, 5}, 10, 5, 30, true, true},
+		{Point{0, 0}, Point{100, 20}, 200, 20, 45, false, false},
+	}
+	for _, tolerance := range []float64{1, 1e-2, 1e-5} {
+		for i, arc := range arcs {
+			cubics := arc.Cubics(tolerance)
+			if cubics[0].Start != arc.Start || cubics[len(cubics)-1].End != arc.End {
+				t.Error("arc", i, "has the wrong endpoints")
+			}
+			params, _ := arc.Params()
+			sin, cos := math.Sincos(-params.Rotation * math.Pi / 180)
+			minRadius := math.Min(params.XRadius, params.YRadius)
+			for j, cubic := range cubics {
+				if j > 0 && !cubic.Start.approxEqual(cubics[j-1].End) {
+					t.Error("arc", i, "has disconnected cubics")
+				}
+
+				// The distance from the ellipse is at least the distance from
+				// the unit circle after undoing the rotation and scale, times
+				// the smallest radius.
+				for k := 0; k <= 100; k++ {
+					p := cubic.Evaluate(float64(k) / 100)
+					x, y := p.X-params.Center.X, p.Y-params.Center.Y
+					x, y = (x*cos-y*sin)/params.XRadius, (x*sin+y*cos)/params.YRadius
+					if d := math.Abs(math.Hypot(x, y)-1) * minRadius; d > tolerance {
+						t.Errorf("arc %d: point %v is at least %f from the arc", i, p, d)
+						break
+					}
+				}
+			}
+		}
+	}
+
+	if n := len(arcs[0].Cubics(1e-2)); n != 1 {
+		t.Error("expected a single cubic for a quarter circle but got", n)
+	}
+	if n := len(arcs[1].Cubics(1e-2)); n != 3 {
+		t.Error("expected three cubics for a three quarter circle but got", n)
+	}
+	if n := len((&Arc{Point{1, 1}, Point{1, 1}, 1, 1, 0, true, true}).Cubics(1)); n != 0 {
+		t.Error("expected no cubics but got", n)
+	}
+}
+
+func TestPathArcsToCubics(t *testing.T) {
+	path, err := ParsePath("M0 0A5 5 0 0 1 10 0A0 5 0 0 1 20 0L20 10a10 10 0 1 1 -10 -10Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	converted := path.ArcsToCubics(1e-3)
+	var lines int
+	for _, cmd := range converted {
+		if cmd.Name == "A" {
+			t.Fatal("arc was not converted:", converted)
+		} else if cmd.Name == "L" {
+			lines++
+		}
+	}
+	if lines != 2 {
+		t.Error("expected the straight arc to become a line:", converted)
+	}
+	expected := NewPathMeasure(path)
+	actual := NewPathMeasure(converted)
+	for i := 0; i <= 100; i++ {
+		distance := actual.Length() * float64(i) / 100
+		p1 := expected.PointAtLength(distance).Point
+		p2 := actual.PointAtLength(distance).Point
+		if (Line{p1, p2}).Length() > 1e-2 {
+			t.Error("at distance", distance, "expected", p1, "but got", p2)
+		}
+	}
+}
+
+func TestQuadraticToCubic(t *testing.T) {
+	q := &QuadraticBezier{Point{1, 2}, Point{7, 10}, Point{-3, 4}}
+	c := q.ToCubic()
+	for i := 0; i <= 10; i++ {
+		frac := float64(i) / 10
+		if expected, actual := q.Evaluate(frac), c.Evaluate(frac); !expected.approxEqual(actual) {
+			t.Error("at", frac, "expected", expected, "but got", actual)
+		}
+	}
+	if c.Classify() != CubicQuadratic {
+		t.Error("unexpected classification:", c.Classify())
+	}
+}
+
+func TestCubicToQuadratics(t *testing.T) {
+	c := &CubicBezier{Point{0, 0}, Point{100, 100}, Point{-50, 100}, Point{50, 0}}
+	var lastCount int
+	for _, tolerance := range []float64{10, 1, 1e-2, 1e-4} {
+		quads := c.ToQuadratics(tolerance)
+		if len(quads) < lastCount {
+			t.Error("tolerance", tolerance, "gave fewer curves than a looser tolerance")
+		}
+		lastCount = len(quads)
+		if quads[0].Start != c.Start || quads[len(quads)-1].End != c.End {
+			t.Error("tolerance", tolerance, "gave the wrong endpoints")
+		}
+		for i, q := range quads {
+			if i > 0 && q.Start != quads[i-1].End {
+				t.Error("tolerance", tolerance, "gave disconnected curves")
+			}
+
+			// Corresponding parameters are never further apart than the
+			// curves themselves are.
+			n := float64(len(quads))
+			for j := 0; j <= 20; j++ {
+				frac := float64(j) / 20
+				expected := c.Evaluate((float64(i) + frac) / n)
+				if d := (Line{expected, q.Evaluate(frac)}).Length(); d > tolerance*1.01 {
+					t.Errorf("tolerance %f: error of %f", tolerance, d)
+					break
+				}
+			}
+		}
+	}
+
+	q := &QuadraticBezier{Point{1, 2}, Point{7, 10}, Point{-3, 4}}
+	if quads := q.ToCubic().ToQuadratics(1e-9); len(quads) != 1 ||
+		!quads[0].Control.approxEqual(q.Control) {
+		t.Error("expected the original curve but got", quads)
+	}
+}
+
+func TestPathDegreeConversion(t *testing.T) {
+	path, err := ParsePath("M0 0Q10 10 20 0T40 0C50 10 60 -10 70 0L80 0Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cubics := path.QuadraticsToCubics()
+	expected := "M0 0C6.6667 6.6667 13.3333 6.6667 20 0C26.6667-6.6667 33.3333-6.6667 40 0" +
+		"C50 10 60-10 70 0L80 0Z"
+	if actual := cubics.Format(FormatOptions{Precision: 4}); actual != expected {
+		t.Error("expected", expected, "but got", actual)
+	}
+	quads := path.CubicsToQuadratics(1e-3)
+	for _, cmd := range quads {
+		if cmd.Name == "C" {
+			t.Fatal("cubic was not converted:", quads)
+		}
+	}
+	if !quads[1].Equals(path.Normalize()[1]) {
+		t.Error("quadratic curve changed:", quads)
+	}
+	if math.Abs(NewPathMeasure(quads).Length()-NewPathMeasure(path).Length()) > 1e-2 {
+		t.Error("length changed")
+	}
+}
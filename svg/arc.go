@@ -2,8 +2,6 @@ package svg
 
 import "math"
 
-const arcLengthApproximationInterval = 0.005
-
 type Arc struct {
 	Start    Point
 	End      Point
@@ -82,6 +80,13 @@ type ArcParams struct {
 	Sweep bool
 }
 
+// Arc converts the arc back into endpoint form, which is the reverse of
+// Arc.Params.
+func (a *ArcParams) Arc() *Arc {
+	return &Arc{a.From(), a.To(), a.XRadius, a.YRadius, a.Rotation,
+		math.Abs(a.angleDelta()) > 180, a.Sweep}
+}
+
 // Bounds computes the bounding box of the arc.
 func (a *ArcParams) Bounds() Rect {
 	minX, maxX := a.minMaxX()
@@ -89,34 +94,24 @@ func (a *ArcParams) Bounds() Rect {
 	return Rect{Point{minX, minY}, Point{maxX, maxY}}
 }
 
-// Length approximates the arc's length.
+// Length computes the arc's length.
 func (a *ArcParams) Length() float64 {
-	var length float64
-	for t := 0.0; t < 1; t += arcLengthApproximationInterval {
-		length += Line{a.Evaluate(t), a.Evaluate(t + arcLengthApproximationInterval)}.Length()
+	return a.LengthWithTolerance(lengthTolerance(a))
+}
+
+// LengthWithTolerance computes the arc's length. Circular arcs are measured
+// exactly, while elliptical arcs are measured with adaptive Gauss-Legendre
+// quadrature so that the result is within the given tolerance.
+func (a *ArcParams) LengthWithTolerance(tolerance float64) float64 {
+	if a.XRadius == a.YRadius {
+		return math.Abs(a.angleDelta()) * math.Pi / 180 * a.XRadius
 	}
-	return length
+	return integrate(speed(a.Derivative), 0, 1, tolerance)
 }
 
 // Evaluate generates a point on the arc for a parameter between 0 and 1.
 func (a *ArcParams) Evaluate(t float64) Point {
-	var angle float64
-	if a.Sweep == (a.EndAngle > a.StartAngle) {
-		angle = a.StartAngle + t*(a.EndAngle-a.StartAngle)
-	} else if !a.Sweep {
-		end := a.EndAngle - 360
-		angle = a.StartAngle + t*(end-a.StartAngle)
-		if angle < 0 {
-			angle += 360
-		}
-	} else {
-		end := a.EndAngle + 360
-		angle = a.StartAngle + t*(end-a.StartAngle)
-		if angle > 360 {
-			angle -= 360
-		}
-	}
-	return a.evaluateAngle(angle)
+	return a.evaluateAngle(a.StartAngle + t*a.angleDelta())
 }
 
 func (a *ArcParams) From() Point {
@@ -127,6 +122,37 @@ func (a *ArcParams) To() Point {
 	return a.Evaluate(1)
 }
 
+// Derivative computes the derivative of the arc with respect to t.
+func (a *ArcParams) Derivative(t float64) Point {
+	delta := a.angleDelta()
+	d := a.angleDerivative(a.StartAngle + t*delta)
+	delta *= math.Pi / 180
+	return Point{delta * d.X, delta * d.Y}
+}
+
+// SecondDerivative computes the second derivative of the arc with respect
+// to t.
+func (a *ArcParams) SecondDerivative(t float64) Point {
+	delta := a.angleDelta() * math.Pi / 180
+	angle := a.StartAngle*math.Pi/180 + t*delta
+	rotSin, rotCos := math.Sincos(math.Pi / 180 * a.Rotation)
+	sin, cos := math.Sincos(angle)
+	return Point{delta * delta * (-a.XRadius*cos*rotCos + a.YRadius*sin*rotSin),
+		delta * delta * (-a.XRadius*cos*rotSin - a.YRadius*sin*rotCos)}
+}
+
+// angleDelta returns the number of degrees that the arc sweeps through. It
+// is positive for arcs with the sweep flag set and negative otherwise.
+func (a *ArcParams) angleDelta() float64 {
+	delta := a.EndAngle - a.StartAngle
+	if a.Sweep && delta < 0 {
+		delta += 360
+	} else if !a.Sweep && delta > 0 {
+		delta -= 360
+	}
+	return delta
+}
+
 func (a *ArcParams) minMaxX() (min, max float64) {
 	x1, x2 := a.Evaluate(0).X, a.Evaluate(1).X
 	min = math.Min(x1, x2)
@@ -192,6 +218,15 @@ func (a *ArcParams) evaluateAngle(angle float64) Point {
 		a.XRadius*math.Cos(angle)*rotSin + a.YRadius*math.Sin(angle)*rotCos + a.Center.Y}
 }
 
+// angleDerivative computes the derivative of evaluateAngle with respect to
+// the angle in radians.
+func (a *ArcParams) angleDerivative(angle float64) Point {
+	rotSin, rotCos := math.Sincos(math.Pi / 180 * a.Rotation)
+	sin, cos := math.Sincos(angle * math.Pi / 180)
+	return Point{-a.XRadius*sin*rotCos - a.YRadius*cos*rotSin,
+		-a.XRadius*sin*rotSin + a.YRadius*cos*rotCos}
+}
+
 func clipDegreesTo360(angle float64) float64 {
 	for angle < 0 {
 		angle += 360
@@ -212,7 +247,8 @@ func angleBetween(v1x, v1y, v2x, v2y float64) float64 {
 	dot := v1x*v2x + v1y*v2y
 	magProduct := math.Sqrt(math.Pow(v1x, 2)+math.Pow(v1y, 2)) *
 		math.Sqrt(math.Pow(v2x, 2)+math.Pow(v2y, 2))
-	angle := math.Acos(dot / magProduct)
+	// Rounding can push the cosine slightly outside of [-1, 1].
+	angle := math.Acos(math.Max(-1, math.Min(1, dot/magProduct)))
 	if v1x*v2y-v1y*v2x < 0 {
 		angle *= -1
 	}
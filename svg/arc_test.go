@@ -1,6 +1,9 @@
 package svg
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestArcBounds(t *testing.T) {
 	arcs := []Arc{
@@ -38,3 +41,24 @@ func TestArcBounds(t *testing.T) {
 		}
 	}
 }
+
+func TestArcLength(t *testing.T) {
+	arcs := []Arc{
+		{Point{10, 10}, Point{30, 30}, 20, 20, 0, false, true},
+		{Point{10, 10}, Point{30, 30}, 20, 20, 0, true, true},
+		{Point{50, 10}, Point{60, 20}, 10, 20, 30, true, false},
+		{Point{60, 20}, Point{50, 10}, 10, 20, 30, false, true},
+		{Point{10, 10}, Point{30, 10}, 20, 10, 90, false, false},
+	}
+	for i, arc := range arcs {
+		params, _ := arc.Params()
+		expected := chordLength(params)
+		if actual := params.LengthWithTolerance(1e-9); math.Abs(actual-expected) > 1e-6 {
+			t.Error("arc", i, "should have length", expected, "but got", actual)
+		}
+	}
+	semicircle, _ := (&Arc{Point{0, 0}, Point{20, 0}, 10, 10, 0, false, true}).Params()
+	if length := semicircle.Length(); math.Abs(length-10*math.Pi) > 1e-9 {
+		t.Error("semicircle should have length", 10*math.Pi, "but got", length)
+	}
+}
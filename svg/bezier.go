@@ -2,9 +2,6 @@ package svg
 
 import "math"
 
-const quadLengthApproximationInterval = 0.01
-const cubicLengthApproximationInterval = 0.005
-
 // A QuadraticBezier represents a 2nd degree Bezier curve
 type QuadraticBezier struct {
 	Start   Point
@@ -19,14 +16,37 @@ func (q *QuadraticBezier) Bounds() Rect {
 	return Rect{Point{minX, minY}, Point{maxX, maxY}}
 }
 
-// Length approximates the length of the curve.
+// Length computes the length of the curve.
 func (q *QuadraticBezier) Length() float64 {
-	var length float64
-	for t := float64(0); t < 1; t += quadLengthApproximationInterval {
-		segment := Line{q.Evaluate(t), q.Evaluate(t + quadLengthApproximationInterval)}
-		length += segment.Length()
+	return q.LengthWithTolerance(lengthTolerance(q))
+}
+
+// LengthWithTolerance computes the length of the curve. A closed-form
+// solution is used when possible, in which case the tolerance only limits
+// the error for curves whose control points are collinear.
+func (q *QuadraticBezier) LengthWithTolerance(tolerance float64) float64 {
+	// The derivative is 2*(a + b*t), so the speed is 2*sqrt(A*t^2 + B*t + C).
+	a := Point{q.Control.X - q.Start.X, q.Control.Y - q.Start.Y}
+	b := Point{q.End.X - 2*q.Control.X + q.Start.X, q.End.Y - 2*q.Control.Y + q.Start.Y}
+	A := b.X*b.X + b.Y*b.Y
+	B := 2 * (a.X*b.X + a.Y*b.Y)
+	C := a.X*a.X + a.Y*a.Y
+
+	discriminant := 4*A*C - B*B
+	if A <= 1e-12*C || discriminant <= 1e-12*A*C {
+		// When the control points are collinear, the speed may reach zero
+		// and the logarithm below breaks down. When the curve is nearly a
+		// line traced at a constant speed, dividing by A loses all precision.
+		return integrate(speed(q.Derivative), 0, 1, tolerance)
 	}
-	return length
+
+	sqrtA := math.Sqrt(A)
+	endSpeed := math.Sqrt(A + B + C)
+	startSpeed := math.Sqrt(C)
+	integral := ((2*A+B)*endSpeed-B*startSpeed)/(4*A) +
+		discriminant/(8*A*sqrtA)*
+			math.Log((2*sqrtA*endSpeed+2*A+B)/(2*sqrtA*startSpeed+B))
+	return 2 * integral
 }
 
 // Evaluate gets a point on the bezier curve for a parameter between 0 and 1.
@@ -46,6 +66,21 @@ func (q *QuadraticBezier) To() Point {
 	return q.End
 }
 
+// Derivative computes the derivative of the curve with respect to t.
+func (q *QuadraticBezier) Derivative(t float64) Point {
+	return Point{
+		2 * ((1-t)*(q.Control.X-q.Start.X) + t*(q.End.X-q.Control.X)),
+		2 * ((1-t)*(q.Control.Y-q.Start.Y) + t*(q.End.Y-q.Control.Y)),
+	}
+}
+
+// SecondDerivative computes the second derivative of the curve, which is the
+// same for every t.
+func (q *QuadraticBezier) SecondDerivative(t float64) Point {
+	return Point{2 * (q.End.X - 2*q.Control.X + q.Start.X),
+		2 * (q.End.Y - 2*q.Control.Y + q.Start.Y)}
+}
+
 func quadraticBezierExtrema(A, B, C float64) (min, max float64) {
 	min = math.Min(A, C)
 	max = math.Max(A, C)
@@ -90,14 +125,15 @@ func (c *CubicBezier) Bounds() Rect {
 	return Rect{Point{minX, minY}, Point{maxX, maxY}}
 }
 
-// Length approximates the length of the curve.
+// Length computes the length of the curve.
 func (c *CubicBezier) Length() float64 {
-	var length float64
-	for t := float64(0); t < 1; t += cubicLengthApproximationInterval {
-		segment := Line{c.Evaluate(t), c.Evaluate(t + cubicLengthApproximationInterval)}
-		length += segment.Length()
-	}
-	return length
+	return c.LengthWithTolerance(lengthTolerance(c))
+}
+
+// LengthWithTolerance computes the length of the curve using adaptive
+// Gauss-Legendre quadrature, so the result is within the given tolerance.
+func (c *CubicBezier) LengthWithTolerance(tolerance float64) float64 {
+	return integrate(speed(c.Derivative), 0, 1, tolerance)
 }
 
 // Evaluate gets a point on the bezier curve for a parameter between 0 and 1.
@@ -117,6 +153,21 @@ func (c *CubicBezier) To() Point {
 	return c.End
 }
 
+// Derivative computes the derivative of the curve with respect to t.
+func (c *CubicBezier) Derivative(t float64) Point {
+	x := cubicBezierDerivative(c.Start.X, c.Control1.X, c.Control2.X, c.End.X, t)
+	y := cubicBezierDerivative(c.Start.Y, c.Control1.Y, c.Control2.Y, c.End.Y, t)
+	return Point{x, y}
+}
+
+// SecondDerivative computes the second derivative of the curve with respect
+// to t.
+func (c *CubicBezier) SecondDerivative(t float64) Point {
+	x := cubicBezierSecondDerivative(c.Start.X, c.Control1.X, c.Control2.X, c.End.X, t)
+	y := cubicBezierSecondDerivative(c.Start.Y, c.Control1.Y, c.Control2.Y, c.End.Y, t)
+	return Point{x, y}
+}
+
 func cubicBezierExtrema(A, B, C, D float64) []float64 {
 	// These coefficients result from taking the derivative of the cubic bezier
 	// polynomial.
@@ -143,3 +194,11 @@ func cubicBezierExtrema(A, B, C, D float64) []float64 {
 func cubicBezierPolynomial(A, B, C, D, t float64) float64 {
 	return A*math.Pow(1-t, 3) + 3*B*t*math.Pow(1-t, 2) + 3*C*(1-t)*t*t + D*t*t*t
 }
+
+func cubicBezierDerivative(A, B, C, D, t float64) float64 {
+	return 3*(1-t)*(1-t)*(B-A) + 6*(1-t)*t*(C-B) + 3*t*t*(D-C)
+}
+
+func cubicBezierSecondDerivative(A, B, C, D, t float64) float64 {
+	return 6*(1-t)*(C-2*B+A) + 6*t*(D-2*C+B)
+}
@@ -1,6 +1,9 @@
 package svg
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestQuadBezierCurveBounds(t *testing.T) {
 	l := Line{Point{10, 10}, Point{40, 40}}
@@ -47,3 +50,70 @@ func TestCubicBezierCurveBounds(t *testing.T) {
 		}
 	}
 }
+
+func TestBezierLength(t *testing.T) {
+	quads := []QuadraticBezier{
+		{Point{10, 50}, Point{20, 10}, Point{30, 50}},
+		{Point{10, 10}, Point{40, 40}, Point{20, 50}},
+		{Point{0, 0}, Point{100, 0}, Point{50, 0}},
+		{Point{0, 0}, Point{5, 5}, Point{10, 10}},
+		{Point{1, 1}, Point{1, 1}, Point{1, 1}},
+	}
+	for i, q := range quads {
+		expected := chordLength(&q)
+		if actual := q.LengthWithTolerance(1e-9); math.Abs(actual-expected) > 1e-6 {
+			t.Error("quadratic", i, "should have length", expected, "but got", actual)
+		}
+	}
+
+	cubics := []CubicBezier{
+		{Point{10, 50}, Point{40, 10}, Point{70, 90}, Point{100, 50}},
+		{Point{96, 89}, Point{13, 46}, Point{14, 64}, Point{15, 91}},
+		{Point{0, 0}, Point{100, 100}, Point{0, 100}, Point{100, 0}},
+		{Point{0, 0}, Point{1, 0}, Point{9, 0}, Point{3, 0}},
+	}
+	for i, c := range cubics {
+		expected := chordLength(&c)
+		if actual := c.LengthWithTolerance(1e-9); math.Abs(actual-expected) > 1e-6 {
+			t.Error("cubic", i, "should have length", expected, "but got", actual)
+		}
+	}
+}
+
+func TestBezierLengthScale(t *testing.T) {
+	c := &CubicBezier{Point{0, 0}, Point{100, 100}, Point{0, 100}, Point{100, 0}}
+	for _, scale := range []float64{1e-6, 1e8} {
+		scaled := &CubicBezier{Point{0, 0}, Point{100 * scale, 100 * scale},
+			Point{0, 100 * scale}, Point{100 * scale, 0}}
+		expected := c.Length() * scale
+		if actual := scaled.Length(); math.Abs(actual-expected) > 1e-8*expected {
+			t.Error("at scale", scale, "expected length", expected, "but got", actual)
+		}
+
+		// An absolute tolerance beyond the precision of the length still
+		// gives an accurate result.
+		actual := scaled.LengthWithTolerance(1e-9 * scale * scale)
+		if math.Abs(actual-expected) > 1e-8*expected {
+			t.Error("at scale", scale, "expected length", expected, "but got", actual)
+		}
+	}
+}
+
+func TestQuadraticBezierLengthStraight(t *testing.T) {
+	// The second derivative of this curve is tiny but not zero.
+	q := &QuadraticBezier{Point{54, 0.49777777777777743}, Point{55, 2.220446049250313e-16},
+		Point{56, -0.49777777777777743}}
+	expected := Line{q.Start, q.End}.Length()
+	if length := q.Length(); math.Abs(length-expected) > 1e-6 {
+		t.Error("expected length", expected, "but got", length)
+	}
+}
+
+func chordLength(s PathSegment) float64 {
+	const steps = 200000
+	var length float64
+	for i := 0; i < steps; i++ {
+		length += Line{s.Evaluate(float64(i) / steps), s.Evaluate(float64(i+1) / steps)}.Length()
+	}
+	return length
+}
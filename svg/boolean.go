@@ -0,0 +1,487 @@
+package svg
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// booleanPrecision is the size, relative to the paths, below which
+	// boolean operations treat distances as zero.
+	booleanPrecision = 1e-9
+
+	// booleanFlatness is the distance, relative to the paths, within which a
+	// piece of a curve is treated as a line when intersecting it.
+	booleanFlatness = 1e-7
+
+	// maxIntersectionDepth limits how many times curves are subdivided when
+	// looking for their intersections.
+	maxIntersectionDepth = 50
+)
+
+// A BooleanOp is a way of combining the insides of two paths.
+type BooleanOp int
+
+const (
+	// UnionOp keeps points inside of either path.
+	UnionOp BooleanOp = iota
+
+	// IntersectionOp keeps points inside of both paths.
+	IntersectionOp
+
+	// DifferenceOp keeps points inside of the first path but not the second.
+	DifferenceOp
+
+	// XorOp keeps points inside of exactly one of the paths.
+	XorOp
+)
+
+func (b BooleanOp) apply(inA, inB bool) bool {
+	switch b {
+	case UnionOp:
+		return inA || inB
+	case IntersectionOp:
+		return inA && inB
+	case DifferenceOp:
+		return inA && !inB
+	case XorOp:
+		return inA != inB
+	}
+	panic("unknown boolean operation")
+}
+
+// Union combines the insides of two paths, using the NonZero fill rule.
+func Union(a, b Path) Path {
+	return Boolean(UnionOp, a, NonZero, b, NonZero)
+}
+
+// Intersection finds the inside shared by two paths, using the NonZero fill
+// rule.
+func Intersection(a, b Path) Path {
+	return Boolean(IntersectionOp, a, NonZero, b, NonZero)
+}
+
+// Difference removes the inside of b from the inside of a, using the NonZero
+// fill rule.
+func Difference(a, b Path) Path {
+	return Boolean(DifferenceOp, a, NonZero, b, NonZero)
+}
+
+// Xor finds the points inside of exactly one of two paths, using the NonZero
+// fill rule.
+func Xor(a, b Path) Path {
+	return Boolean(XorOp, a, NonZero, b, NonZero)
+}
+
+// Boolean combines the insides of two paths, according to their fill rules,
+// and returns the outline of the result. Open subpaths are closed, like they
+// are when a path is filled.
+//
+// The outline is made of the pieces of the paths' segments between their
+// intersections, so lines stay lines and curves stay curves. Arcs and
+// quadratic curves are converted to cubic curves, and curves are split where
+// they turn horizontal or vertical. Collinear lines are merged. Every subpath
+// of the result is closed and has the inside on the side of its Normals, so
+// the result fills the same way with either fill rule. Both paths must be
+// validated first.
+func Boolean(op BooleanOp, a Path, aRule FillRule, b Path, bRule FillRule) Path {
+	size := booleanSize(a, b)
+	precision := size * booleanPrecision
+	edgesA := booleanEdges(a, size*booleanFlatness)
+	edgesB := booleanEdges(b, size*booleanFlatness)
+	edges := append(append([]PathSegment{}, edgesA...), edgesB...)
+
+	// Intersections are found to within the flatness, so points which are
+	// that close are treated as the same.
+	flatness := size * booleanFlatness
+
+	var fragments []PathSegment
+	for _, edge := range splitAtIntersections(edges, flatness, precision) {
+		if pointsMeet(edge.From(), edge.To(), flatness) {
+			// Edges only go one way, so this one is too small to matter.
+			continue
+		}
+		mid := edge.Evaluate(0.5)
+		normal := Normal(edge, 0.5)
+		inside := func(distance float64) bool {
+			p := offsetPoint(mid, normal, distance)
+			return op.apply(fills(windingNumber(edgesA, p), aRule),
+				fills(windingNumber(edgesB, p), bRule))
+		}
+		inFront, inBehind := inside(flatness), inside(-flatness)
+		if inFront == inBehind {
+			continue
+		} else if !inFront {
+			edge = reverseSegment(edge)
+		}
+		if !containsFragment(fragments, edge, flatness) {
+			fragments = append(fragments, edge)
+		}
+	}
+
+	var loops []Subpath
+	for _, loop := range chainFragments(fragments, flatness) {
+		loops = append(loops, Subpath{Segments: mergeLines(loop), Closed: true})
+	}
+	return PathFromSubpaths(loops)
+}
+
+// booleanSize finds the size of the paths' bounding box, which scales the
+// distances used by boolean operations.
+func booleanSize(paths ...Path) float64 {
+	bounds := Rect{Point{math.Inf(1), math.Inf(1)}, Point{math.Inf(-1), math.Inf(-1)}}
+	for _, p := range paths {
+		for _, segment := range p.Segments() {
+			b := segment.Bounds()
+			bounds.Min = Point{math.Min(bounds.Min.X, b.Min.X), math.Min(bounds.Min.Y, b.Min.Y)}
+			bounds.Max = Point{math.Max(bounds.Max.X, b.Max.X), math.Max(bounds.Max.Y, b.Max.Y)}
+		}
+	}
+	size := math.Max(bounds.Width(), bounds.Height())
+	if !(size > 0) || math.IsInf(size, 0) {
+		return 1
+	}
+	return size
+}
+
+// booleanEdges converts a path into closed loops of lines and cubic curves
+// which only go one way horizontally and vertically. Arcs are approximated
+// within the tolerance.
+func booleanEdges(p Path, tolerance float64) []PathSegment {
+	var res []PathSegment
+	for _, subpath := range p.Subpaths() {
+		segments := subpath.Segments
+		start, end := segments[0].From(), segments[len(segments)-1].To()
+		if start != end {
+			segments = append(segments, Line{end, start})
+		}
+		for _, segment := range segments {
+			if degenerateSegment(segment) {
+				continue
+			}
+			switch segment := segment.(type) {
+			case Line:
+				res = append(res, segment)
+			case *QuadraticBezier:
+				res = append(res, segment.ToCubic().monotonePieces()...)
+			case *CubicBezier:
+				res = append(res, segment.monotonePieces()...)
+			case *ArcParams:
+				for _, cubic := range segment.Cubics(tolerance) {
+					res = append(res, cubic.monotonePieces()...)
+				}
+			}
+		}
+	}
+	return res
+}
+
+// monotonePieces splits the curve where its x or y derivative vanishes.
+func (c *CubicBezier) monotonePieces() []PathSegment {
+	a, b, d := c.powerBasis()
+	var splits []float64
+	for _, t := range append(quadraticRoots(d.X, 2*b.X, a.X),
+		quadraticRoots(d.Y, 2*b.Y, a.Y)...) {
+		if t > 1e-9 && t < 1-1e-9 {
+			splits = append(splits, t)
+		}
+	}
+	splits = append(append([]float64{0}, splits...), 1)
+	sort.Float64s(splits)
+
+	var res []PathSegment
+	for i := 1; i < len(splits); i++ {
+		if splits[i]-splits[i-1] < 1e-9 {
+			continue
+		}
+		piece := c.Subsegment(splits[i-1], splits[i])
+		if !degenerateSegment(piece) {
+			res = append(res, piece)
+		}
+	}
+	if len(res) > 0 {
+		res[0].(*CubicBezier).Start = c.Start
+		res[len(res)-1].(*CubicBezier).End = c.End
+	}
+	return res
+}
+
+// An edgeSplit is a point at which an edge is split, with its parameter.
+type edgeSplit struct {
+	t     float64
+	point Point
+}
+
+// splitAtIntersections splits edges wherever they cross or touch another edge.
+func splitAtIntersections(edges []PathSegment, flatness, precision float64) []PathSegment {
+	splits := make([][]edgeSplit, len(edges))
+	for i, edge := range edges {
+		for j := i + 1; j < len(edges); j++ {
+			for _, hit := range intersectSegments(edge, edges[j], flatness, precision) {
+				t1, t2 := hit[0], hit[1]
+				// Intersections at the end of an edge are at the end exactly.
+				point := edge.Evaluate(t1)
+				if t1 <= 0 {
+					point = edge.From()
+				} else if t1 >= 1 {
+					point = edge.To()
+				} else if t2 <= 0 {
+					point = edges[j].From()
+				} else if t2 >= 1 {
+					point = edges[j].To()
+				}
+				splits[i] = append(splits[i], edgeSplit{t1, point})
+				splits[j] = append(splits[j], edgeSplit{t2, point})
+			}
+		}
+	}
+
+	var res []PathSegment
+	for i, edge := range edges {
+		edgeSplits := splits[i]
+		sort.Slice(edgeSplits, func(j, k int) bool {
+			return edgeSplits[j].t < edgeSplits[k].t
+		})
+		lastT, lastPoint := 0.0, edge.From()
+		for _, split := range append(edgeSplits, edgeSplit{1, edge.To()}) {
+			if (Line{lastPoint, split.point}).Length() <= precision ||
+				split.t <= lastT {
+				continue
+			}
+			piece := subsegment(edge, lastT, split.t)
+			res = append(res, withEndpoints(piece, lastPoint, split.point))
+			lastT, lastPoint = split.t, split.point
+		}
+	}
+	return res
+}
+
+// intersectSegments finds the parameters at which two lines or cubic curves
+// meet. Where they overlap, the ends of the overlap are found.
+func intersectSegments(s1, s2 PathSegment, flatness, precision float64) [][2]float64 {
+	var res [][2]float64
+	var search func(p1, p2 PathSegment, lo1, hi1, lo2, hi2 float64, depth int)
+	search = func(p1, p2 PathSegment, lo1, hi1, lo2, hi2 float64, depth int) {
+		if !boundsOverlap(p1.Bounds(), p2.Bounds(), precision) {
+			return
+		}
+		flat1, flat2 := isFlat(p1, flatness), isFlat(p2, flatness)
+		if (flat1 && flat2) || depth >= maxIntersectionDepth {
+			for _, hit := range intersectLines(Line{p1.From(), p1.To()},
+				Line{p2.From(), p2.To()}, precision) {
+				res = append(res, [2]float64{lo1 + hit[0]*(hi1-lo1), lo2 + hit[1]*(hi2-lo2)})
+			}
+			return
+		}
+		mid1, mid2 := (lo1+hi1)/2, (lo2+hi2)/2
+		if flat2 || (!flat1 && hi1-lo1 >= hi2-lo2) {
+			search(subsegment(s1, lo1, mid1), p2, lo1, mid1, lo2, hi2, depth+1)
+			search(subsegment(s1, mid1, hi1), p2, mid1, hi1, lo2, hi2, depth+1)
+		} else {
+			search(p1, subsegment(s2, lo2, mid2), lo1, hi1, lo2, mid2, depth+1)
+			search(p1, subsegment(s2, mid2, hi2), lo1, hi1, mid2, hi2, depth+1)
+		}
+	}
+	search(s1, s2, 0, 1, 0, 1, 0)
+
+	// Neighboring pieces of a curve find the same intersection.
+	sort.Slice(res, func(i, j int) bool {
+		return res[i][0] < res[j][0]
+	})
+	var unique [][2]float64
+	for _, hit := range res {
+		if len(unique) > 0 {
+			last := unique[len(unique)-1]
+			if pointsMeet(s1.Evaluate(hit[0]), s1.Evaluate(last[0]), flatness) &&
+				pointsMeet(s2.Evaluate(hit[1]), s2.Evaluate(last[1]), flatness) {
+				continue
+			}
+		}
+		unique = append(unique, hit)
+	}
+	return unique
+}
+
+// intersectLines finds the parameters at which two line segments meet, or
+// the ends of their overlap if they are collinear.
+func intersectLines(l1, l2 Line, precision float64) [][2]float64 {
+	d1 := Point{l1.End.X - l1.Start.X, l1.End.Y - l1.Start.Y}
+	d2 := Point{l2.End.X - l2.Start.X, l2.End.Y - l2.Start.Y}
+	len1, len2 := norm(d1), norm(d2)
+	if len1 == 0 || len2 == 0 {
+		return nil
+	}
+	offset := Point{l2.Start.X - l1.Start.X, l2.Start.Y - l1.Start.Y}
+	denominator := cross(d1, d2)
+	if math.Abs(denominator) <= precision*math.Max(len1, len2) {
+		// The lines are parallel, so they only meet if they overlap.
+		if math.Abs(cross(d1, offset))/len1 > precision {
+			return nil
+		}
+		var res [][2]float64
+		for _, t := range []float64{0, 1} {
+			if u := dot(Point{l2.Evaluate(t).X - l1.Start.X, l2.Evaluate(t).Y - l1.Start.Y},
+				d1) / (len1 * len1); u >= 0 && u <= 1 {
+				res = append(res, [2]float64{u, t})
+			}
+			if u := dot(Point{l1.Evaluate(t).X - l2.Start.X, l1.Evaluate(t).Y - l2.Start.Y},
+				d2) / (len2 * len2); u >= 0 && u <= 1 {
+				res = append(res, [2]float64{t, u})
+			}
+		}
+		return res
+	}
+	t1 := cross(offset, d2) / denominator
+	t2 := cross(offset, d1) / denominator
+	slack1, slack2 := precision/len1, precision/len2
+	if t1 < -slack1 || t1 > 1+slack1 || t2 < -slack2 || t2 > 1+slack2 {
+		return nil
+	}
+	return [][2]float64{{math.Max(0, math.Min(1, t1)), math.Max(0, math.Min(1, t2))}}
+}
+
+func boundsOverlap(b1, b2 Rect, precision float64) bool {
+	return b1.Min.X <= b2.Max.X+precision && b2.Min.X <= b1.Max.X+precision &&
+		b1.Min.Y <= b2.Max.Y+precision && b2.Min.Y <= b1.Max.Y+precision
+}
+
+// isFlat checks if a line or cubic curve is within a distance of its chord.
+func isFlat(s PathSegment, flatness float64) bool {
+	c, ok := s.(*CubicBezier)
+	return !ok || (lineDistance(c.Control1, c.Start, c.End) <= flatness &&
+		lineDistance(c.Control2, c.Start, c.End) <= flatness)
+}
+
+// withEndpoints moves the ends of a line or cubic curve.
+func withEndpoints(s PathSegment, start, end Point) PathSegment {
+	switch s := s.(type) {
+	case Line:
+		return Line{start, end}
+	case *CubicBezier:
+		return &CubicBezier{start, s.Control1, s.Control2, end}
+	}
+	panic("unsupported segment type")
+}
+
+// windingNumber counts how many times edges from booleanEdges wind around a
+// point, by finding where a ray from the point to the right crosses them.
+func windingNumber(edges []PathSegment, p Point) int {
+	var res int
+	for _, edge := range edges {
+		start, end := edge.From(), edge.To()
+		winding := 1
+		if start.Y > end.Y {
+			start, end = end, start
+			winding = -1
+		}
+		if p.Y < start.Y || p.Y >= end.Y || p.X >= math.Max(start.X, end.X) {
+			continue
+		}
+		if p.X < math.Min(start.X, end.X) {
+			res += winding
+			continue
+		}
+
+		// Since the edge is monotonic, the crossing can be found by bisection.
+		lo, hi := 0.0, 1.0
+		for i := 0; i < 60; i++ {
+			mid := (lo + hi) / 2
+			if (edge.Evaluate(mid).Y < p.Y) == (winding == 1) {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		if edge.Evaluate((lo+hi)/2).X > p.X {
+			res += winding
+		}
+	}
+	return res
+}
+
+func fills(winding int, rule FillRule) bool {
+	if rule == EvenOdd {
+		return winding%2 != 0
+	}
+	return winding != 0
+}
+
+// containsFragment checks if a fragment is already in a list, treating points
+// within the tolerance of each other as the same.
+func containsFragment(fragments []PathSegment, fragment PathSegment, tolerance float64) bool {
+	for _, f := range fragments {
+		if pointsMeet(f.From(), fragment.From(), tolerance) &&
+			pointsMeet(f.To(), fragment.To(), tolerance) &&
+			pointsMeet(f.Evaluate(0.5), fragment.Evaluate(0.5), tolerance) {
+			return true
+		}
+	}
+	return false
+}
+
+// chainFragments joins fragments end to start into closed loops, treating
+// points within the tolerance of each other as the same. Chains which do not
+// close are left out, since they can only come from rounding errors.
+func chainFragments(fragments []PathSegment, tolerance float64) [][]PathSegment {
+	used := make([]bool, len(fragments))
+	var res [][]PathSegment
+	for i, fragment := range fragments {
+		if used[i] {
+			continue
+		}
+		used[i] = true
+		loop := []PathSegment{fragment}
+		start := fragment.From()
+		closed := pointsMeet(fragment.To(), start, tolerance)
+		for !closed {
+			end := loop[len(loop)-1].To()
+			next := -1
+			for j, candidate := range fragments {
+				if !used[j] && pointsMeet(candidate.From(), end, tolerance) {
+					next = j
+					break
+				}
+			}
+			if next == -1 {
+				break
+			}
+			used[next] = true
+			loop = append(loop, withEndpoints(fragments[next], end, fragments[next].To()))
+			closed = pointsMeet(fragments[next].To(), start, tolerance)
+		}
+		if !closed {
+			continue
+		}
+		last := loop[len(loop)-1]
+		loop[len(loop)-1] = withEndpoints(last, last.From(), start)
+		res = append(res, loop)
+	}
+	return res
+}
+
+func pointsMeet(p1, p2 Point, tolerance float64) bool {
+	return Line{p1, p2}.Length() <= tolerance
+}
+
+// mergeLines merges neighboring lines of a closed loop which go the same way.
+func mergeLines(loop []PathSegment) []PathSegment {
+	collinear := func(s1, s2 PathSegment) bool {
+		l1, ok1 := s1.(Line)
+		l2, ok2 := s2.(Line)
+		return ok1 && ok2 && math.Abs(cross(Tangent(l1, 0), Tangent(l2, 0))) < 1e-9 &&
+			dot(Tangent(l1, 0), Tangent(l2, 0)) > 0
+	}
+	var res []PathSegment
+	for _, segment := range loop {
+		if len(res) > 0 && collinear(res[len(res)-1], segment) {
+			res[len(res)-1] = Line{res[len(res)-1].From(), segment.To()}
+		} else {
+			res = append(res, segment)
+		}
+	}
+	if len(res) > 1 && collinear(res[len(res)-1], res[0]) {
+		res[0] = Line{res[len(res)-1].From(), res[0].To()}
+		res = res[:len(res)-1]
+	}
+	return res
+}
@@ -0,0 +1,166 @@
+package svg
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestBooleanSquares(t *testing.T) {
+	a, _ := ParsePath("M2 2H12V12H2Z")
+	b, _ := ParsePath("M7 17V7H17V17Z")
+	expected := map[BooleanOp]float64{UnionOp: 175, IntersectionOp: 25, DifferenceOp: 75,
+		XorOp: 150}
+	for op, area := range expected {
+		result := Boolean(op, a, NonZero, b, NonZero)
+		if err := result.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		for _, cmd := range result {
+			if cmd.Name != "M" && cmd.Name != "L" && cmd.Name != "Z" {
+				t.Error("unexpected command in", result)
+			}
+		}
+		for _, rule := range []FillRule{NonZero, EvenOdd} {
+			if actual := booleanArea(result, rule); math.Abs(actual-area) > 1e-3 {
+				t.Error("operation", op, "expected area", area, "but got", actual)
+			}
+		}
+	}
+
+	if result := Intersection(a, b); len(result.Segments()) != 4 {
+		t.Error("expected a square but got", result)
+	}
+	adjacent, _ := ParsePath("M12 2H22V12H12Z")
+	if result := Union(a, adjacent); len(result.Segments()) != 4 {
+		t.Error("expected a rectangle but got", result)
+	}
+	if result := Intersection(a, adjacent); len(result) != 0 {
+		t.Error("expected nothing but got", result)
+	}
+	if result := Union(a, a); booleanArea(result, NonZero) != 100 {
+		t.Error("unexpected union with itself:", result)
+	}
+}
+
+func TestBooleanCircles(t *testing.T) {
+	a, _ := NewPathBuilder().Circle(8, 10, 5).Path()
+	b, _ := NewPathBuilder().Circle(12, 10, 5).Path()
+
+	// The lens where the circles overlap has an area of
+	// 2*r^2*acos(d/(2r)) - d/2*sqrt(4r^2 - d^2).
+	lens := 2*25*math.Acos(0.4) - 2*math.Sqrt(100-16)
+	circle := math.Pi * 25
+	expected := map[BooleanOp]float64{UnionOp: 2*circle - lens, IntersectionOp: lens,
+		DifferenceOp: circle - lens, XorOp: 2 * (circle - lens)}
+	for op, area := range expected {
+		result := Boolean(op, a, NonZero, b, NonZero)
+		for _, cmd := range result {
+			if cmd.Name == "L" {
+				t.Error("operation", op, "turned curves into lines:", result)
+				break
+			}
+		}
+		if actual := booleanArea(result, NonZero); math.Abs(actual-area) > 0.05 {
+			t.Error("operation", op, "expected area", area, "but got", actual)
+		}
+	}
+}
+
+func TestBooleanFillRules(t *testing.T) {
+	// A star whose middle is only filled with the NonZero fill rule.
+	star, _ := ParsePath("M10 1L15.3 17.3L1.4 7.2H18.6L4.7 17.3Z")
+	square, _ := ParsePath("M0 0H20V20H0Z")
+	for _, rule := range []FillRule{NonZero, EvenOdd} {
+		for _, op := range []BooleanOp{UnionOp, IntersectionOp, DifferenceOp, XorOp} {
+			checkBoolean(t, op, star, rule, square, NonZero)
+			checkBoolean(t, op, square, NonZero, star, rule)
+		}
+	}
+
+	open, _ := ParsePath("M3 3L17 3L10 15")
+	for _, op := range []BooleanOp{UnionOp, IntersectionOp, DifferenceOp, XorOp} {
+		checkBoolean(t, op, open, NonZero, star, EvenOdd)
+	}
+}
+
+func TestBooleanCurves(t *testing.T) {
+	a, _ := ParsePath("M2 10C2 -2 18 -2 18 10Q10 25 2 10ZM8 8A2 3 30 1 0 12 8Z")
+	b, _ := ParsePath("M10 2C25 5 -5 15 10 18C0 18 0 2 10 2Z")
+	for _, op := range []BooleanOp{UnionOp, IntersectionOp, DifferenceOp, XorOp} {
+		checkBoolean(t, op, a, NonZero, b, EvenOdd)
+	}
+}
+
+func TestBooleanScale(t *testing.T) {
+	squareA, _ := ParsePath("M2 2H12V12H2Z")
+	squareB, _ := ParsePath("M7 17V7H17V17Z")
+	circleA, _ := NewPathBuilder().Circle(8, 10, 5).Path()
+	circleB, _ := NewPathBuilder().Circle(12, 10, 5).Path()
+	for _, scale := range []float64{1e-6, 1e8} {
+		for _, shapes := range [][2]Path{{squareA, squareB}, {circleA, circleB}} {
+			a := shapes[0].Transform(ScaleMatrix(scale, scale))
+			b := shapes[1].Transform(ScaleMatrix(scale, scale))
+			for _, op := range []BooleanOp{UnionOp, IntersectionOp, DifferenceOp, XorOp} {
+				expected := Boolean(op, shapes[0], NonZero, shapes[1], NonZero)
+				result := Boolean(op, a, NonZero, b, NonZero)
+				subpaths, segments := len(result.Subpaths()), len(result.Segments())
+				if subpaths != len(expected.Subpaths()) || segments != len(expected.Segments()) {
+					t.Errorf("scale %g, operation %d: got %d subpaths and %d segments", scale,
+						op, subpaths, segments)
+					continue
+				}
+				unscaled := result.Transform(ScaleMatrix(1/scale, 1/scale))
+				area := booleanArea(expected, NonZero)
+				if actual := booleanArea(unscaled, NonZero); math.Abs(actual-area) > 1e-2 {
+					t.Errorf("scale %g, operation %d: expected area %f but got %f", scale, op,
+						area, actual)
+				}
+			}
+		}
+	}
+}
+
+// checkBoolean compares a boolean operation to the fills of its arguments,
+// at every pixel which each argument covers fully or not at all.
+func checkBoolean(t *testing.T, op BooleanOp, a Path, aRule FillRule, b Path, bRule FillRule) {
+	t.Helper()
+	result := Boolean(op, a, aRule, b, bRule)
+	images := []*image.RGBA{}
+	for _, fill := range []struct {
+		path Path
+		rule FillRule
+	}{{a, aRule}, {b, bRule}, {result, NonZero}} {
+		img := image.NewRGBA(image.Rect(0, 0, 100, 100))
+		FillPath(img, fill.path.Transform(ScaleMatrix(5, 5)), color.Black, fill.rule)
+		images = append(images, img)
+	}
+	for i := 3; i < len(images[0].Pix); i += 4 {
+		alphaA, alphaB := images[0].Pix[i], images[1].Pix[i]
+		if (alphaA != 0 && alphaA != 255) || (alphaB != 0 && alphaB != 255) {
+			continue
+		}
+		expected := uint8(0)
+		if op.apply(alphaA == 255, alphaB == 255) {
+			expected = 255
+		}
+		if actual := images[2].Pix[i]; math.Abs(float64(actual)-float64(expected)) > 1 {
+			x, y := (i/4)%100, (i/4)/100
+			t.Errorf("operation %d: pixel (%d, %d) should be %d but is %d: %v", op, x, y,
+				expected, actual, result)
+			return
+		}
+	}
+}
+
+// booleanArea finds the area of a path between (0, 0) and (25, 25).
+func booleanArea(p Path, rule FillRule) float64 {
+	img := image.NewRGBA(image.Rect(0, 0, 250, 250))
+	FillPath(img, p.Transform(ScaleMatrix(10, 10)), color.Black, rule)
+	var area float64
+	for i := 3; i < len(img.Pix); i += 4 {
+		area += float64(img.Pix[i]) / 255
+	}
+	return area / 100
+}
@@ -0,0 +1,289 @@
+package svg
+
+import (
+	"errors"
+	"math"
+)
+
+// A PathBuilder constructs a path one command at a time. Its methods return
+// the builder itself, so calls can be chained:
+//
+//	path, err := NewPathBuilder().MoveTo(0, 0).LineTo(10, 0).LineTo(10, 10).Close().Path()
+//
+// Each method adds a single command, using the lowercase command letter for
+// relative variants. Invalid calls, like a LineTo before any MoveTo, are not
+// added to the path. Instead, the first such error is returned by Path.
+type PathBuilder struct {
+	path         Path
+	currentPoint Point
+	subpathStart Point
+	err          error
+}
+
+// NewPathBuilder creates a builder with an empty path.
+func NewPathBuilder() *PathBuilder {
+	return &PathBuilder{path: Path{}}
+}
+
+// Path returns a copy of the path built so far, or the first error caused by
+// an invalid call.
+func (b *PathBuilder) Path() (Path, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	res := make(Path, len(b.path))
+	for i, cmd := range b.path {
+		res[i] = cmd.Clone()
+	}
+	if err := res.Validate(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CurrentPoint returns the point at which the next command will start.
+func (b *PathBuilder) CurrentPoint() Point {
+	return b.currentPoint
+}
+
+// MoveTo starts a new subpath at (x, y).
+func (b *PathBuilder) MoveTo(x, y float64) *PathBuilder {
+	if b.add(PathCmd{"M", []float64{x, y}}, Point{x, y}, false) {
+		b.subpathStart = b.currentPoint
+	}
+	return b
+}
+
+// MoveToRel starts a new subpath at an offset from the current point.
+func (b *PathBuilder) MoveToRel(dx, dy float64) *PathBuilder {
+	end := b.relative(dx, dy)
+	if b.add(PathCmd{"m", []float64{dx, dy}}, end, false) {
+		b.subpathStart = b.currentPoint
+	}
+	return b
+}
+
+// LineTo draws a line to (x, y).
+func (b *PathBuilder) LineTo(x, y float64) *PathBuilder {
+	b.add(PathCmd{"L", []float64{x, y}}, Point{x, y}, true)
+	return b
+}
+
+// LineToRel draws a line to an offset from the current point.
+func (b *PathBuilder) LineToRel(dx, dy float64) *PathBuilder {
+	b.add(PathCmd{"l", []float64{dx, dy}}, b.relative(dx, dy), true)
+	return b
+}
+
+// HLineTo draws a horizontal line to the x coordinate x.
+func (b *PathBuilder) HLineTo(x float64) *PathBuilder {
+	b.add(PathCmd{"H", []float64{x}}, Point{x, b.currentPoint.Y}, true)
+	return b
+}
+
+// HLineToRel draws a horizontal line of length dx.
+func (b *PathBuilder) HLineToRel(dx float64) *PathBuilder {
+	b.add(PathCmd{"h", []float64{dx}}, b.relative(dx, 0), true)
+	return b
+}
+
+// VLineTo draws a vertical line to the y coordinate y.
+func (b *PathBuilder) VLineTo(y float64) *PathBuilder {
+	b.add(PathCmd{"V", []float64{y}}, Point{b.currentPoint.X, y}, true)
+	return b
+}
+
+// VLineToRel draws a vertical line of length dy.
+func (b *PathBuilder) VLineToRel(dy float64) *PathBuilder {
+	b.add(PathCmd{"v", []float64{dy}}, b.relative(0, dy), true)
+	return b
+}
+
+// CubicTo draws a cubic Bezier curve to (x, y) with the control points
+// (x1, y1) and (x2, y2).
+func (b *PathBuilder) CubicTo(x1, y1, x2, y2, x, y float64) *PathBuilder {
+	b.add(PathCmd{"C", []float64{x1, y1, x2, y2, x, y}}, Point{x, y}, true)
+	return b
+}
+
+// CubicToRel is like CubicTo, but every point is an offset from the current
+// point.
+func (b *PathBuilder) CubicToRel(dx1, dy1, dx2, dy2, dx, dy float64) *PathBuilder {
+	b.add(PathCmd{"c", []float64{dx1, dy1, dx2, dy2, dx, dy}}, b.relative(dx, dy), true)
+	return b
+}
+
+// QuadTo draws a quadratic Bezier curve to (x, y) with the control point
+// (x1, y1).
+func (b *PathBuilder) QuadTo(x1, y1, x, y float64) *PathBuilder {
+	b.add(PathCmd{"Q", []float64{x1, y1, x, y}}, Point{x, y}, true)
+	return b
+}
+
+// QuadToRel is like QuadTo, but every point is an offset from the current
+// point.
+func (b *PathBuilder) QuadToRel(dx1, dy1, dx, dy float64) *PathBuilder {
+	b.add(PathCmd{"q", []float64{dx1, dy1, dx, dy}}, b.relative(dx, dy), true)
+	return b
+}
+
+// ArcTo draws an elliptical arc to (x, y). The arguments have the same
+// meaning as those of the "A" command. The radii may not be negative.
+func (b *PathBuilder) ArcTo(rx, ry, rotation float64, largeArc, sweep bool,
+	x, y float64) *PathBuilder {
+	b.arc("A", rx, ry, rotation, largeArc, sweep, x, y, Point{x, y})
+	return b
+}
+
+// ArcToRel is like ArcTo, but the end point is an offset from the current
+// point.
+func (b *PathBuilder) ArcToRel(rx, ry, rotation float64, largeArc, sweep bool,
+	dx, dy float64) *PathBuilder {
+	b.arc("a", rx, ry, rotation, largeArc, sweep, dx, dy, b.relative(dx, dy))
+	return b
+}
+
+// Close closes the current subpath, drawing a line back to its start.
+func (b *PathBuilder) Close() *PathBuilder {
+	b.add(PathCmd{"Z", []float64{}}, b.subpathStart, true)
+	return b
+}
+
+// Rect adds a closed rectangle with its top-left corner at (x, y). Like the
+// <rect> element, it starts at the top-left corner and runs clockwise when
+// the y axis points down. The width and height may not be negative.
+func (b *PathBuilder) Rect(x, y, width, height float64) *PathBuilder {
+	if width < 0 || height < 0 {
+		return b.fail("negative rectangle size")
+	}
+	return b.MoveTo(x, y).HLineTo(x + width).VLineTo(y + height).HLineTo(x).Close()
+}
+
+// RoundedRect adds a closed rectangle with elliptical corners. Like the
+// <rect> element, the radii are limited to half of the width and height, and
+// a rectangle with a zero radius has square corners.
+func (b *PathBuilder) RoundedRect(x, y, width, height, rx, ry float64) *PathBuilder {
+	if width < 0 || height < 0 {
+		return b.fail("negative rectangle size")
+	} else if rx < 0 || ry < 0 {
+		return b.fail("negative radius")
+	}
+	rx = math.Min(rx, width/2)
+	ry = math.Min(ry, height/2)
+	if rx == 0 || ry == 0 {
+		return b.Rect(x, y, width, height)
+	}
+	return b.MoveTo(x+rx, y).
+		HLineTo(x+width-rx).
+		ArcTo(rx, ry, 0, false, true, x+width, y+ry).
+		VLineTo(y+height-ry).
+		ArcTo(rx, ry, 0, false, true, x+width-rx, y+height).
+		HLineTo(x+rx).
+		ArcTo(rx, ry, 0, false, true, x, y+height-ry).
+		VLineTo(y+ry).
+		ArcTo(rx, ry, 0, false, true, x+rx, y).
+		Close()
+}
+
+// Circle adds a closed circle centered at (cx, cy).
+func (b *PathBuilder) Circle(cx, cy, r float64) *PathBuilder {
+	return b.Ellipse(cx, cy, r, r)
+}
+
+// Ellipse adds a closed, axis-aligned ellipse centered at (cx, cy). Like the
+// <ellipse> element, it starts at the rightmost point and is made of four
+// arcs.
+func (b *PathBuilder) Ellipse(cx, cy, rx, ry float64) *PathBuilder {
+	if rx < 0 || ry < 0 {
+		return b.fail("negative radius")
+	}
+	return b.MoveTo(cx+rx, cy).
+		ArcTo(rx, ry, 0, false, true, cx, cy+ry).
+		ArcTo(rx, ry, 0, false, true, cx-rx, cy).
+		ArcTo(rx, ry, 0, false, true, cx, cy-ry).
+		ArcTo(rx, ry, 0, false, true, cx+rx, cy).
+		Close()
+}
+
+// Polygon adds a closed polygon through the points. At least one point is
+// required.
+func (b *PathBuilder) Polygon(points ...Point) *PathBuilder {
+	if len(points) == 0 {
+		return b.fail("polygon has no points")
+	}
+	b.MoveTo(points[0].X, points[0].Y)
+	for _, point := range points[1:] {
+		b.LineTo(point.X, point.Y)
+	}
+	return b.Close()
+}
+
+// Star adds a closed star centered at (cx, cy) with the given number of
+// points. The tips of the points are outerRadius from the center, and the
+// corners between them are innerRadius from the center. The first tip points
+// in the negative y direction, which is up in SVG.
+func (b *PathBuilder) Star(cx, cy float64, points int, outerRadius,
+	innerRadius float64) *PathBuilder {
+	if points < 2 {
+		return b.fail("star has fewer than two points")
+	} else if outerRadius < 0 || innerRadius < 0 {
+		return b.fail("negative radius")
+	}
+	corners := make([]Point, 2*points)
+	for i := range corners {
+		radius := outerRadius
+		if i%2 == 1 {
+			radius = innerRadius
+		}
+		sin, cos := math.Sincos(math.Pi*float64(i)/float64(points) - math.Pi/2)
+		corners[i] = Point{cx + radius*cos, cy + radius*sin}
+	}
+	return b.Polygon(corners...)
+}
+
+func (b *PathBuilder) arc(name string, rx, ry, rotation float64, largeArc, sweep bool,
+	x, y float64, end Point) {
+	if rx < 0 || ry < 0 {
+		b.fail("negative radius")
+		return
+	}
+	var largeArcFlag, sweepFlag float64
+	if largeArc {
+		largeArcFlag = 1
+	}
+	if sweep {
+		sweepFlag = 1
+	}
+	b.add(PathCmd{name, []float64{rx, ry, rotation, largeArcFlag, sweepFlag, x, y}}, end, true)
+}
+
+// add appends a command which moves the current point to end. Drawing
+// commands are rejected if no subpath has been started.
+func (b *PathBuilder) add(cmd PathCmd, end Point, drawing bool) bool {
+	if b.err != nil {
+		return false
+	} else if drawing && len(b.path) == 0 {
+		b.err = errors.New("path must start with a moveto command")
+		return false
+	}
+	for _, arg := range cmd.Args {
+		if math.IsNaN(arg) || math.IsInf(arg, 0) {
+			b.err = errors.New("invalid argument to " + cmd.Name + " command")
+			return false
+		}
+	}
+	b.path = append(b.path, cmd)
+	b.currentPoint = end
+	return true
+}
+
+func (b *PathBuilder) relative(dx, dy float64) Point {
+	return Point{b.currentPoint.X + dx, b.currentPoint.Y + dy}
+}
+
+func (b *PathBuilder) fail(message string) *PathBuilder {
+	if b.err == nil {
+		b.err = errors.New(message)
+	}
+	return b
+}
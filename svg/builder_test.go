@@ -0,0 +1,104 @@
+package svg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPathBuilder(t *testing.T) {
+	path, err := NewPathBuilder().
+		MoveTo(1, 2).
+		LineTo(3, 4).
+		HLineToRel(2).
+		VLineTo(-1).
+		CubicTo(1, 1, 2, 2, 3, 3).
+		QuadToRel(1, 0, 2, 2).
+		ArcTo(5, 4, 30, true, false, 10, 10).
+		Close().
+		MoveToRel(1, 1).
+		LineToRel(-1, 0).
+		ArcToRel(1, 1, 0, false, true, 0, 2).
+		Path()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "M1 2L3 4h2V-1C1 1 2 2 3 3q1 0 2 2A5 4 30 1 0 10 10Zm1 1l-1 0a1 1 0 0 1 0 2"
+	if actual := path.String(); actual != expected {
+		t.Error("expected", expected, "but got", actual)
+	}
+}
+
+func TestPathBuilderCurrentPoint(t *testing.T) {
+	b := NewPathBuilder().MoveTo(1, 2).LineToRel(3, 4).Close()
+	if !b.CurrentPoint().approxEqual(Point{1, 2}) {
+		t.Error("unexpected point after close:", b.CurrentPoint())
+	}
+	b.MoveToRel(1, 1).VLineToRel(2).HLineTo(7)
+	if !b.CurrentPoint().approxEqual(Point{7, 5}) {
+		t.Error("unexpected point:", b.CurrentPoint())
+	}
+}
+
+func TestPathBuilderShapes(t *testing.T) {
+	tests := []struct {
+		builder  *PathBuilder
+		expected string
+	}{
+		{NewPathBuilder().Rect(1, 2, 3, 4), "M1 2H4V6H1Z"},
+		{NewPathBuilder().RoundedRect(1, 2, 3, 4, 0, 1), "M1 2H4V6H1Z"},
+		{NewPathBuilder().RoundedRect(0, 0, 4, 6, 3, 1), "M2 0H2A2 1 0 0 1 4 1V5" +
+			"A2 1 0 0 1 2 6H2A2 1 0 0 1 0 5V1A2 1 0 0 1 2 0Z"},
+		{NewPathBuilder().Circle(5, 5, 2), "M7 5A2 2 0 0 1 5 7A2 2 0 0 1 3 5" +
+			"A2 2 0 0 1 5 3A2 2 0 0 1 7 5Z"},
+		{NewPathBuilder().Polygon(Point{1, 2}, Point{3, 4}, Point{5, 0}), "M1 2L3 4L5 0Z"},
+	}
+	for i, test := range tests {
+		path, err := test.builder.Path()
+		if err != nil {
+			t.Error("test", i, "failed:", err)
+		} else if actual := path.String(); actual != test.expected {
+			t.Error("test", i, "should give", test.expected, "but gave", actual)
+		}
+	}
+}
+
+func TestPathBuilderStar(t *testing.T) {
+	path, err := NewPathBuilder().Star(10, 20, 5, 8, 3).Path()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(path) != 11 || path[0].Name != "M" || path[10].Name != "Z" {
+		t.Fatal("unexpected structure:", path)
+	}
+	if start := (Point{path[0].Args[0], path[0].Args[1]}); !start.approxEqual(Point{10, 12}) {
+		t.Error("first tip should point up but is at", start)
+	}
+	for i, cmd := range path[:10] {
+		radius := math.Hypot(cmd.Args[0]-10, cmd.Args[1]-20)
+		expected := 8.0
+		if i%2 == 1 {
+			expected = 3
+		}
+		if math.Abs(radius-expected) > 1e-9 {
+			t.Error("corner", i, "should be", expected, "from the center but is", radius)
+		}
+	}
+}
+
+func TestPathBuilderErrors(t *testing.T) {
+	builders := []*PathBuilder{
+		NewPathBuilder().LineTo(1, 2),
+		NewPathBuilder().Close(),
+		NewPathBuilder().MoveTo(math.NaN(), 0),
+		NewPathBuilder().MoveTo(0, 0).ArcTo(-1, 1, 0, false, false, 1, 1),
+		NewPathBuilder().Rect(0, 0, -1, 1),
+		NewPathBuilder().Circle(0, 0, -1),
+		NewPathBuilder().Polygon(),
+		NewPathBuilder().Star(0, 0, 1, 2, 1),
+	}
+	for i, b := range builders {
+		if _, err := b.MoveTo(0, 0).LineTo(1, 1).Path(); err == nil {
+			t.Error("builder", i, "should have failed")
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package svg
+
+import (
+	"math"
+	"sort"
+	"strconv"
+)
+
+// curvatureExtremaSamples is the number of intervals searched for sign
+// changes when looking for curvature extrema.
+const curvatureExtremaSamples = 256
+
+// Curvature computes the signed curvature of a segment at parameter t, which
+// is the reciprocal of the radius of the osculating circle. It is positive
+// where the segment turns towards its Normal. At a cusp, the curvature is
+// infinite or NaN.
+func Curvature(s PathSegment, t float64) float64 {
+	d1 := s.Derivative(t)
+	d2 := s.SecondDerivative(t)
+	speed := math.Hypot(d1.X, d1.Y)
+	return cross(d1, d2) / (speed * speed * speed)
+}
+
+// A CubicType classifies the shape of a cubic Bezier curve, considering the
+// curve for every parameter and not just those between 0 and 1.
+type CubicType int
+
+const (
+	// CubicSerpentine curves have one or two inflection points.
+	CubicSerpentine CubicType = iota
+
+	// CubicLoop curves cross themselves.
+	CubicLoop
+
+	// CubicCusp curves have a point where their derivative vanishes.
+	CubicCusp
+
+	// CubicQuadratic curves are degree-elevated quadratic Bezier curves.
+	CubicQuadratic
+
+	// CubicLine curves have collinear control points.
+	CubicLine
+)
+
+var cubicTypeNames = []string{"serpentine", "loop", "cusp", "quadratic", "line"}
+
+// String returns the name of the type, like "serpentine".
+func (c CubicType) String() string {
+	if c < 0 || int(c) >= len(cubicTypeNames) {
+		return "CubicType(" + strconv.Itoa(int(c)) + ")"
+	}
+	return cubicTypeNames[c]
+}
+
+// Classify determines the shape of the curve, following the classification
+// of Loop and Blinn. Curves whose only other inflection is at infinity are
+// considered serpentines.
+func (c *CubicBezier) Classify() CubicType {
+	a, b, d := c.powerBasis()
+	scale := math.Max(math.Max(norm(a), norm(b)), norm(d))
+	if scale == 0 {
+		return CubicLine
+	}
+	A, B, C := c.inflectionPolynomial()
+	epsilon := 1e-12 * scale * scale
+	if math.Abs(A) <= epsilon && math.Abs(B) <= epsilon && math.Abs(C) <= epsilon {
+		return CubicLine
+	} else if norm(d) <= 1e-12*scale {
+		return CubicQuadratic
+	} else if math.Abs(A) <= epsilon {
+		return CubicSerpentine
+	}
+	discriminant := B*B - 4*A*C
+	if math.Abs(discriminant) <= 1e-9*(B*B+math.Abs(4*A*C)) {
+		return CubicCusp
+	} else if discriminant > 0 {
+		return CubicSerpentine
+	}
+	return CubicLoop
+}
+
+// Inflections returns the parameters strictly between 0 and 1 at which the
+// curvature changes sign, in increasing order.
+func (c *CubicBezier) Inflections() []float64 {
+	// At a cusp, the polynomial's double root is not an inflection.
+	if kind := c.Classify(); kind == CubicLine || kind == CubicCusp {
+		return []float64{}
+	}
+	A, B, C := c.inflectionPolynomial()
+	res := []float64{}
+	for _, t := range quadraticRoots(A, B, C) {
+		if t > 0 && t < 1 {
+			res = append(res, t)
+		}
+	}
+	sort.Float64s(res)
+	return res
+}
+
+// Cusp returns the parameter at which a CubicCusp curve's derivative
+// vanishes, if it is between 0 and 1.
+func (c *CubicBezier) Cusp() (float64, bool) {
+	if c.Classify() != CubicCusp {
+		return 0, false
+	}
+	A, B, _ := c.inflectionPolynomial()
+	t := -B / (2 * A)
+	return t, t >= 0 && t <= 1
+}
+
+// Loop returns the two parameters at which a CubicLoop curve crosses itself,
+// in increasing order, if they are both between 0 and 1.
+func (c *CubicBezier) Loop() (t1, t2 float64, ok bool) {
+	if c.Classify() != CubicLoop {
+		return 0, 0, false
+	}
+
+	// With the curve written as P0 + 3a*t + 3b*t^2 + d*t^3, the curve meets
+	// itself at t1 != t2 when 3a + 3b*s + d*(s^2 - p) = 0, where s = t1 + t2
+	// and p = t1*t2. Crossing this with d eliminates p.
+	a, b, d := c.powerBasis()
+	s := -cross(d, a) / cross(d, b)
+	v := Point{3*a.X + 3*b.X*s + d.X*s*s, 3*a.Y + 3*b.Y*s + d.Y*s*s}
+	p := dot(v, d) / dot(d, d)
+	roots := quadraticRoots(1, -s, p)
+	if len(roots) != 2 {
+		return 0, 0, false
+	}
+	t1, t2 = math.Min(roots[0], roots[1]), math.Max(roots[0], roots[1])
+	return t1, t2, t1 >= 0 && t2 <= 1
+}
+
+// CurvatureExtrema returns the parameters strictly between 0 and 1 at which
+// the magnitude of the curvature has a local minimum or maximum, in
+// increasing order. Cusps are not included.
+func (c *CubicBezier) CurvatureExtrema() []float64 {
+	if c.Classify() == CubicLine {
+		return []float64{}
+	}
+
+	// The derivative of the curvature is proportional to this function.
+	_, _, d := c.powerBasis()
+	d3 := Point{6 * d.X, 6 * d.Y}
+	f := func(t float64) float64 {
+		d1 := c.Derivative(t)
+		d2 := c.SecondDerivative(t)
+		return cross(d1, d3)*dot(d1, d1) - 3*cross(d1, d2)*dot(d1, d2)
+	}
+
+	res := []float64{}
+	start := c.Derivative(0)
+	scale := dot(start, start) + dot(c.Derivative(1), c.Derivative(1))
+	for i := 0; i < curvatureExtremaSamples; i++ {
+		lo := float64(i) / curvatureExtremaSamples
+		hi := float64(i+1) / curvatureExtremaSamples
+		fLo, fHi := f(lo), f(hi)
+		if fLo == 0 && i > 0 {
+			res = append(res, lo)
+			continue
+		} else if (fLo < 0) == (fHi < 0) || fHi == 0 {
+			continue
+		}
+		t := bisectRoot(f, lo, hi, fLo)
+		if d1 := c.Derivative(t); dot(d1, d1) > 1e-12*scale {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// powerBasis writes the curve as P0 + 3a*t + 3b*t^2 + d*t^3.
+func (c *CubicBezier) powerBasis() (a, b, d Point) {
+	a = Point{c.Control1.X - c.Start.X, c.Control1.Y - c.Start.Y}
+	b = Point{c.Control2.X - 2*c.Control1.X + c.Start.X,
+		c.Control2.Y - 2*c.Control1.Y + c.Start.Y}
+	d = Point{c.End.X - 3*c.Control2.X + 3*c.Control1.X - c.Start.X,
+		c.End.Y - 3*c.Control2.Y + 3*c.Control1.Y - c.Start.Y}
+	return
+}
+
+// inflectionPolynomial returns the coefficients of A*t^2 + B*t + C, which is
+// proportional to the cross product of the first and second derivatives.
+func (c *CubicBezier) inflectionPolynomial() (A, B, C float64) {
+	a, b, d := c.powerBasis()
+	return cross(b, d), cross(a, d), cross(a, b)
+}
+
+// quadraticRoots finds the real roots of a*x^2 + b*x + c. Linear equations are
+// solved when a is 0.
+func quadraticRoots(a, b, c float64) []float64 {
+	if a == 0 {
+		if b == 0 {
+			return nil
+		}
+		return []float64{-c / b}
+	}
+	discriminant := b*b - 4*a*c
+	if discriminant < 0 {
+		return nil
+	} else if discriminant == 0 {
+		return []float64{-b / (2 * a)}
+	}
+
+	// This form avoids cancellation when b*b is much larger than 4*a*c.
+	q := -(b + math.Copysign(math.Sqrt(discriminant), b)) / 2
+	return []float64{q / a, c / q}
+}
+
+// bisectRoot finds a root of f between lo and hi, where f changes sign.
+func bisectRoot(f func(float64) float64, lo, hi, fLo float64) float64 {
+	for i := 0; i < 60; i++ {
+		mid := (lo + hi) / 2
+		fMid := f(mid)
+		if fMid == 0 {
+			return mid
+		} else if (fMid < 0) == (fLo < 0) {
+			lo, fLo = mid, fMid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+func cross(p1, p2 Point) float64 {
+	return p1.X*p2.Y - p1.Y*p2.X
+}
+
+func dot(p1, p2 Point) float64 {
+	return p1.X*p2.X + p1.Y*p2.Y
+}
+
+func norm(p Point) float64 {
+	return math.Hypot(p.X, p.Y)
+}
@@ -0,0 +1,90 @@
+package svg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCurvature(t *testing.T) {
+	arc, _ := (&Arc{Point{0, 0}, Point{20, 0}, 10, 10, 0, false, true}).Params()
+	if k := Curvature(arc, 0.3); math.Abs(k-0.1) > 1e-9 {
+		t.Error("clockwise circle should have curvature 0.1 but got", k)
+	}
+	arc.Sweep = false
+	if k := Curvature(arc, 0.3); math.Abs(k+0.1) > 1e-9 {
+		t.Error("counter-clockwise circle should have curvature -0.1 but got", k)
+	}
+	if k := Curvature(Line{Point{0, 0}, Point{1, 1}}, 0.5); k != 0 {
+		t.Error("line should have no curvature but got", k)
+	}
+}
+
+func TestClassifyCubic(t *testing.T) {
+	cases := []struct {
+		curve CubicBezier
+		kind  CubicType
+	}{
+		{CubicBezier{Point{0, 0}, Point{1, 1}, Point{2, 2}, Point{3, 3}}, CubicLine},
+		{CubicBezier{Point{0, 0}, Point{5, 0}, Point{-2, 0}, Point{3, 0}}, CubicLine},
+		{CubicBezier{Point{0, 0}, Point{2, 4}, Point{4, 4}, Point{6, 0}}, CubicQuadratic},
+		{CubicBezier{Point{0, 0}, Point{1, 1}, Point{0, 1}, Point{1, 0}}, CubicCusp},
+		{CubicBezier{Point{0, 0}, Point{10, 10}, Point{-5, 10}, Point{5, 0}}, CubicLoop},
+		{CubicBezier{Point{0, 0}, Point{10, 10}, Point{20, -10}, Point{30, 0}}, CubicSerpentine},
+		{CubicBezier{Point{0, 0}, Point{0, 10}, Point{10, 10}, Point{10, 0}}, CubicLoop},
+	}
+	for i, c := range cases {
+		if kind := c.curve.Classify(); kind != c.kind {
+			t.Error("case", i, "should be", c.kind, "but got", kind)
+		}
+	}
+}
+
+func TestCubicFeatures(t *testing.T) {
+	serpentine := &CubicBezier{Point{0, 0}, Point{10, 10}, Point{20, -10}, Point{30, 0}}
+	inflections := serpentine.Inflections()
+	if len(inflections) != 1 || math.Abs(inflections[0]-0.5) > 1e-9 {
+		t.Error("expected an inflection at 0.5 but got", inflections)
+	}
+	for _, x := range []float64{0.45, 0.55} {
+		if math.Signbit(Curvature(serpentine, x)) == math.Signbit(Curvature(serpentine, 1-x)) {
+			t.Error("curvature should change sign across the inflection")
+		}
+	}
+
+	cusp := &CubicBezier{Point{0, 0}, Point{1, 1}, Point{0, 1}, Point{1, 0}}
+	if x, ok := cusp.Cusp(); !ok || math.Abs(x-0.5) > 1e-9 {
+		t.Error("expected a cusp at 0.5 but got", x, ok)
+	}
+	if inflections := cusp.Inflections(); len(inflections) != 0 {
+		t.Error("cusp should have no inflections but got", inflections)
+	}
+
+	loop := &CubicBezier{Point{0, 0}, Point{10, 10}, Point{-5, 10}, Point{5, 0}}
+	t1, t2, ok := loop.Loop()
+	if !ok || t1 >= t2 {
+		t.Fatal("expected a loop but got", t1, t2, ok)
+	}
+	if p1, p2 := loop.Evaluate(t1), loop.Evaluate(t2); !p1.approxEqual(p2) {
+		t.Error("loop parameters should meet but give", p1, p2)
+	}
+
+	arch := &CubicBezier{Point{0, 0}, Point{0, 10}, Point{10, 10}, Point{10, 0}}
+	if _, _, ok := arch.Loop(); ok {
+		t.Error("arch should not cross itself between 0 and 1")
+	}
+
+	for i, curve := range []*CubicBezier{serpentine, loop,
+		{Point{96, 89}, Point{13, 46}, Point{14, 64}, Point{15, 91}}} {
+		extrema := curve.CurvatureExtrema()
+		if len(extrema) == 0 {
+			t.Error("curve", i, "should have curvature extrema")
+		}
+		for _, x := range extrema {
+			k := math.Abs(Curvature(curve, x))
+			k1, k2 := math.Abs(Curvature(curve, x-1e-4)), math.Abs(Curvature(curve, x+1e-4))
+			if (k-k1)*(k-k2) < 0 {
+				t.Error("curve", i, "has no curvature extremum at", x)
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package svg
+
+import (
+	"errors"
+	"math"
+)
+
+// Dash splits a path into dashes, like the SVG stroke-dasharray and
+// stroke-dashoffset properties. The pattern alternates between the lengths of
+// dashes and the gaps between them, and it is repeated twice if it has an odd
+// number of entries. The offset is how far into the pattern the path starts.
+//
+// Every subpath starts the pattern over, and each dash becomes a subpath of
+// the result, made of the parts of the original segments that it covers. A
+// dash which covers the start of a closed subpath continues into the dash at
+// its end. Dashes with no length become a moveto followed by a line to the
+// same point, so that they still get caps when they are stroked.
+//
+// If every entry of the pattern is zero, a copy of the path is returned. It is
+// an error for an entry to be negative. The path must be valid.
+func (p Path) Dash(pattern []float64, offset float64) (Path, error) {
+	var period float64
+	for _, length := range pattern {
+		if length < 0 || math.IsNaN(length) || math.IsInf(length, 0) {
+			return nil, errors.New("invalid dash length")
+		}
+		period += length
+	}
+	if math.IsNaN(offset) || math.IsInf(offset, 0) {
+		return nil, errors.New("invalid dash offset")
+	}
+	if period == 0 {
+		res := make(Path, len(p))
+		for i, cmd := range p {
+			res[i] = cmd.Clone()
+		}
+		return res, nil
+	}
+	if len(pattern)%2 == 1 {
+		pattern = append(append([]float64{}, pattern...), pattern...)
+		period *= 2
+	}
+
+	// Find the entry of the pattern at the start of each subpath, and how much
+	// of it is left.
+	startIndex := 0
+	startRemaining := pattern[0]
+	phase := math.Mod(offset, period)
+	if phase < 0 {
+		phase += period
+	}
+	for phase > 0 && phase >= startRemaining {
+		phase -= startRemaining
+		startIndex = (startIndex + 1) % len(pattern)
+		startRemaining = pattern[startIndex]
+	}
+	startRemaining -= phase
+
+	var dashes []Subpath
+	for _, subpath := range p.Subpaths() {
+		measure := newSegmentMeasure(subpath.Segments)
+		length := measure.Length()
+		if subpath.Closed && startIndex%2 == 0 && startRemaining >= length {
+			dashes = append(dashes, subpath)
+			continue
+		}
+
+		var subpathDashes []Subpath
+		var position float64
+		index, remaining := startIndex, startRemaining
+		for {
+			end := math.Min(length, position+remaining)
+			if index%2 == 0 {
+				segments := measure.segmentsBetween(position, end)
+				if len(segments) == 0 {
+					point := measure.PointAtLength(position).Point
+					segments = []PathSegment{Line{point, point}}
+				}
+				subpathDashes = append(subpathDashes, Subpath{Segments: segments})
+			}
+			if position+remaining >= length {
+				break
+			}
+			position = end
+			index = (index + 1) % len(pattern)
+			remaining = pattern[index]
+		}
+
+		// The dashes at either end of a closed subpath meet at its start.
+		if subpath.Closed && len(subpathDashes) > 1 && startIndex%2 == 0 && index%2 == 0 {
+			last := &subpathDashes[len(subpathDashes)-1]
+			last.Segments = append(last.Segments, subpathDashes[0].Segments...)
+			subpathDashes = subpathDashes[1:]
+		}
+		dashes = append(dashes, subpathDashes...)
+	}
+	return PathFromSubpaths(dashes), nil
+}
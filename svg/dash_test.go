@@ -0,0 +1,97 @@
+package svg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDash(t *testing.T) {
+	tests := []struct {
+		path    string
+		pattern []float64
+		offset  float64
+		dashed  string
+	}{
+		{"M0 0H10", []float64{3, 1}, 0, "M0 0L3 0M4 0L7 0M8 0L10 0"},
+		{"M0 0H10", []float64{3, 1}, 2, "M0 0L1 0M2 0L5 0M6 0L9 0"},
+		{"M0 0H10", []float64{3, 1}, -1, "M1 0L4 0M5 0L8 0M9 0L10 0"},
+		{"M0 0H10", []float64{3}, 0, "M0 0L3 0M6 0L9 0"},
+		{"M0 0H10", []float64{0, 5}, 0, "M0 0L0 0M5 0L5 0"},
+		{"M0 0H4V4M10 0H14", []float64{5, 1}, 0, "M0 0L4 0L4 1M4 2L4 4M10 0L14 0"},
+		{"M0 0H10V10H0Z", []float64{5, 10}, 0, "M0 0L5 0M10 5L10 10M0 10L0 5"},
+		{"M0 0H10V10H0Z", []float64{6, 4}, 2, "M8 0L10 0L10 4M10 8L10 10L6 10" +
+			"M2 10L0 10L0 6M0 2L0 0L4 0"},
+		{"M0 0H10V10H0Z", []float64{50}, 0, "M0 0L10 0L10 10L0 10Z"},
+		{"M0 0H10", []float64{0, 0}, 0, "M0 0H10"},
+	}
+	for _, test := range tests {
+		path, err := ParsePath(test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dashed, err := path.Dash(test.pattern, test.offset)
+		if err != nil {
+			t.Error(test.path, "gave error:", err)
+		} else if actual := dashed.String(); actual != test.dashed {
+			t.Error("dashing", test.path, "with", test.pattern, "offset", test.offset,
+				"expected", test.dashed, "but got", actual)
+		}
+	}
+}
+
+func TestDashCurves(t *testing.T) {
+	path, _ := ParsePath("M0 0C10 20 30 -20 40 0A10 10 0 0 1 40 20")
+	measure := NewPathMeasure(path)
+	dashed, err := path.Dash([]float64{2, 3}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, cmd := range dashed {
+		if cmd.Name == "L" {
+			t.Fatal("curves were turned into lines:", dashed)
+		}
+	}
+
+	// Every dash but the first is 2 units long and starts 5 units after the
+	// previous one.
+	subpaths := dashed.Subpaths()
+	if expected := int(math.Ceil((measure.Length() + 1) / 5)); len(subpaths) != expected {
+		t.Fatal("expected", expected, "dashes but got", len(subpaths))
+	}
+	for i, subpath := range subpaths {
+		dashMeasure := newSegmentMeasure(subpath.Segments)
+		start := float64(i)*5 - 1
+		expected := math.Min(start+2, measure.Length()) - math.Max(start, 0)
+		if math.Abs(dashMeasure.Length()-expected) > 1e-6 {
+			t.Error("dash", i, "should have length", expected, "but has", dashMeasure.Length())
+		}
+		p := measure.PointAtLength(math.Max(start, 0)).Point
+		if !subpath.Segments[0].From().approxEqual(p) {
+			t.Error("dash", i, "should start at", p, "but starts at",
+				subpath.Segments[0].From())
+		}
+	}
+}
+
+func TestDashZeroPeriod(t *testing.T) {
+	path, _ := ParsePath("M0 0H10")
+	dashed, err := path.Dash([]float64{0, 0}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dashed[0].Args[0] = 99
+	dashed[1] = PathCmd{Name: "V", Args: []float64{5}}
+	if path.String() != "M0 0H10" {
+		t.Error("dashing modified the path:", path)
+	}
+}
+
+func TestDashErrors(t *testing.T) {
+	path, _ := ParsePath("M0 0H10")
+	if _, err := path.Dash([]float64{1, -1}, 0); err == nil {
+		t.Error("expected an error for a negative length")
+	}
+	if _, err := path.Dash([]float64{1, 1}, math.NaN()); err == nil {
+		t.Error("expected an error for an invalid offset")
+	}
+}
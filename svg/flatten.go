@@ -0,0 +1,87 @@
+package svg
+
+import "math"
+
+// maxFlattenDepth limits how many times a curve may be subdivided when it is
+// flattened, so that tiny tolerances still terminate.
+const maxFlattenDepth = 16
+
+// Flatten approximates the path with polylines, returning the vertices of one
+// polyline for each subpath. Every point on the path is within the tolerance
+// of the polylines. The polyline of a closed subpath ends where it starts.
+// A path must be validated before it can be flattened.
+func (p Path) Flatten(tolerance float64) [][]Point {
+	subpaths := p.Subpaths()
+	res := make([][]Point, len(subpaths))
+	for i, subpath := range subpaths {
+		points := []Point{subpath.Segments[0].From()}
+		for _, segment := range subpath.Segments {
+			points = append(points, segment.Flatten(tolerance)[1:]...)
+		}
+		res[i] = points
+	}
+	return res
+}
+
+// Flatten returns the start and end of the line.
+func (l Line) Flatten(tolerance float64) []Point {
+	return []Point{l.Start, l.End}
+}
+
+// Flatten approximates the curve with a polyline, from its start to its end,
+// which is within the tolerance of the curve. The curve is subdivided until
+// the control points of each piece are within the tolerance of its chord.
+func (q *QuadraticBezier) Flatten(tolerance float64) []Point {
+	return q.flatten(tolerance, maxFlattenDepth, []Point{q.Start})
+}
+
+func (q *QuadraticBezier) flatten(tolerance float64, depth int, res []Point) []Point {
+	if depth == 0 || lineDistance(q.Control, q.Start, q.End) <= tolerance {
+		return append(res, q.End)
+	}
+	left, right := q.Split(0.5)
+	res = left.flatten(tolerance, depth-1, res)
+	return right.flatten(tolerance, depth-1, res)
+}
+
+// Flatten approximates the curve with a polyline, from its start to its end,
+// which is within the tolerance of the curve. The curve is subdivided until
+// the control points of each piece are within the tolerance of its chord.
+func (c *CubicBezier) Flatten(tolerance float64) []Point {
+	return c.flatten(tolerance, maxFlattenDepth, []Point{c.Start})
+}
+
+func (c *CubicBezier) flatten(tolerance float64, depth int, res []Point) []Point {
+	if depth == 0 || (lineDistance(c.Control1, c.Start, c.End) <= tolerance &&
+		lineDistance(c.Control2, c.Start, c.End) <= tolerance) {
+		return append(res, c.End)
+	}
+	left, right := c.Split(0.5)
+	res = left.flatten(tolerance, depth-1, res)
+	return right.flatten(tolerance, depth-1, res)
+}
+
+// Flatten approximates the arc with a polyline, from its start to its end,
+// which is within the tolerance of the arc. The arc's angles are divided
+// evenly, using enough pieces that the gap between each piece and the arc is
+// within the tolerance.
+func (a *ArcParams) Flatten(tolerance float64) []Point {
+	// A chord spanning an angle of theta on a circle of radius r is at most
+	// r*(1-cos(theta/2)) from the circle, and the ellipse is no further from
+	// its chords than its bounding circle is.
+	radius := math.Max(a.XRadius, a.YRadius)
+	maxAngle := 2 * math.Acos(math.Max(-1, 1-tolerance/radius)) * 180 / math.Pi
+	maxPieces := 1 << maxFlattenDepth
+	pieces := maxPieces
+	if maxAngle > 0 {
+		pieces = int(math.Min(float64(maxPieces), math.Ceil(math.Abs(a.angleDelta())/maxAngle)))
+	}
+	if pieces < 1 {
+		pieces = 1
+	}
+	res := make([]Point, pieces+1)
+	for i := range res {
+		res[i] = a.Evaluate(float64(i) / float64(pieces))
+	}
+	return res
+}
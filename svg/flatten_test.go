@@ -0,0 +1,62 @@
+package svg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSegmentFlatten(t *testing.T) {
+	arc, _ := (&Arc{Point{10, 0}, Point{0, 5}, 10, 5, 20, true, false}).Params()
+	segments := []PathSegment{
+		Line{Point{1, 2}, Point{3, 4}},
+		&QuadraticBezier{Point{0, 0}, Point{50, 100}, Point{100, 0}},
+		&CubicBezier{Point{0, 0}, Point{100, 100}, Point{-50, 100}, Point{50, 0}},
+		arc,
+	}
+	for _, tolerance := range []float64{1, 0.1, 0.001} {
+		for i, segment := range segments {
+			points := segment.Flatten(tolerance)
+			if points[0] != segment.From() || !points[len(points)-1].approxEqual(segment.To()) {
+				t.Error("segment", i, "has the wrong endpoints:", points[0], points[len(points)-1])
+			}
+			for j := 0; j <= 1000; j++ {
+				point := segment.Evaluate(float64(j) / 1000)
+				if d := polylineDistance(point, points); d > tolerance {
+					t.Errorf("segment %d: point %v is %f from the polyline", i, point, d)
+					break
+				}
+			}
+		}
+	}
+
+	if n := len(segments[1].Flatten(1)); n > len(segments[1].Flatten(0.001)) || n > 20 {
+		t.Error("unexpected number of points for a loose tolerance:", n)
+	}
+}
+
+func TestPathFlatten(t *testing.T) {
+	path, err := ParsePath("M0 0L10 0Q20 0 20 10ZM30 30M40 40C50 50 60 50 70 40")
+	if err != nil {
+		t.Fatal(err)
+	}
+	polylines := path.Flatten(0.01)
+	if len(polylines) != 2 {
+		t.Fatal("expected 2 polylines but got", len(polylines))
+	}
+	first := polylines[0]
+	if first[0] != (Point{0, 0}) || first[1] != (Point{10, 0}) || first[len(first)-1] != first[0] {
+		t.Error("unexpected first polyline:", first)
+	}
+	if second := polylines[1]; second[0] != (Point{40, 40}) ||
+		second[len(second)-1] != (Point{70, 40}) {
+		t.Error("unexpected second polyline:", second)
+	}
+}
+
+func polylineDistance(p Point, points []Point) float64 {
+	res := math.Inf(1)
+	for i := 1; i < len(points); i++ {
+		res = math.Min(res, lineDistance(p, points[i-1], points[i]))
+	}
+	return res
+}
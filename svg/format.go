@@ -0,0 +1,249 @@
+package svg
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// A FormatMode determines whether Path.Format writes absolute or relative
+// commands.
+type FormatMode int
+
+const (
+	// FormatOriginal keeps each command absolute or relative, as it was.
+	FormatOriginal FormatMode = iota
+
+	// FormatAbsolute writes every command in its absolute form.
+	FormatAbsolute
+
+	// FormatRelative writes every command in its relative form.
+	FormatRelative
+
+	// FormatShortest writes each command in whichever form is shorter.
+	FormatShortest
+)
+
+// IntegerPrecision is a FormatOptions.Precision which rounds every number to
+// an integer.
+const IntegerPrecision = -1
+
+// FormatOptions controls how Path.Format writes a path.
+type FormatOptions struct {
+	// Precision is the maximum number of digits after the decimal point. If it
+	// is 0, numbers are written with as many digits as it takes to represent
+	// them exactly. If it is negative, like IntegerPrecision, numbers are
+	// rounded to integers.
+	Precision int
+
+	Mode FormatMode
+
+	// OmitRepeatedCommands leaves out command letters which a parser would
+	// assume anyway, like the second "L" in "L1 2L3 4" or an "L" right after
+	// an "M".
+	OmitRepeatedCommands bool
+
+	// StripLeadingZeros writes numbers like 0.5 as ".5" and -0.5 as "-.5".
+	StripLeadingZeros bool
+
+	// CompactArcFlags writes the flags of an arc command without separators,
+	// as in "A5 5 0 1110 10".
+	CompactArcFlags bool
+
+	// Pretty writes each command on its own line and separates every command
+	// letter and argument with a space. OmitRepeatedCommands and
+	// CompactArcFlags are ignored.
+	Pretty bool
+}
+
+// Format writes the path as a string which can be used in an SVG file. Unlike
+// String, it writes each call of a command separately, and it can round and
+// compress the path data. Even when rounding, the relative coordinates are
+// computed from the rounded coordinates written before them, so that errors
+// do not build up along the path.
+//
+// Format does not change which commands are used, apart from switching
+// between their absolute and relative forms. A path must be validated before
+// it can be formatted.
+func (p Path) Format(opts FormatOptions) string {
+	if err := p.Validate(); err != nil {
+		panic("path is invalid: " + err.Error())
+	}
+	f := &pathFormatter{opts: opts}
+	original := p.SplitMulticalls()
+	for i, cmd := range p.Absolute().SplitMulticalls() {
+		f.writeCommand(cmd, original[i].Name)
+	}
+	return f.buffer.String()
+}
+
+// A formatToken is a formatted argument of a command.
+type formatToken struct {
+	text string
+	flag bool
+}
+
+type pathFormatter struct {
+	opts   FormatOptions
+	buffer bytes.Buffer
+
+	// These points are where the path being written is, according to the
+	// rounded arguments written so far.
+	currentPoint Point
+	subpathStart Point
+
+	// implicitName is the command a parser would assume if the next command
+	// has no letter, and lastToken is the last argument written.
+	implicitName string
+	lastToken    formatToken
+}
+
+func (f *pathFormatter) writeCommand(cmd PathCmd, originalName string) {
+	var relative bool
+	switch f.opts.Mode {
+	case FormatOriginal:
+		relative = originalName != strings.ToUpper(originalName)
+	case FormatRelative:
+		relative = true
+	case FormatShortest:
+		absTokens, _ := f.commandTokens(cmd, false)
+		relTokens, _ := f.commandTokens(cmd, true)
+		absText := f.render(cmd.Name, absTokens)
+		relText := f.render(strings.ToLower(cmd.Name), relTokens)
+		relative = len(relText) < len(absText)
+	}
+
+	name := cmd.Name
+	if relative {
+		name = strings.ToLower(name)
+	}
+	tokens, end := f.commandTokens(cmd, relative)
+	f.buffer.WriteString(f.render(name, tokens))
+
+	f.currentPoint = end
+	switch name {
+	case "M":
+		f.subpathStart = end
+		f.implicitName = "L"
+	case "m":
+		f.subpathStart = end
+		f.implicitName = "l"
+	case "Z", "z":
+		f.implicitName = ""
+	default:
+		f.implicitName = name
+	}
+	if len(tokens) > 0 {
+		f.lastToken = tokens[len(tokens)-1]
+	}
+}
+
+// commandTokens formats the arguments of an absolute command. It also returns
+// the point at which a parser would think the command ends.
+func (f *pathFormatter) commandTokens(cmd PathCmd, relative bool) ([]formatToken,
+	Point) {
+	var tokens []formatToken
+	end := f.currentPoint
+	coordinate := func(value, origin float64) float64 {
+		if !relative {
+			origin = 0
+		}
+		text, rounded := f.number(value - origin)
+		tokens = append(tokens, formatToken{text: text})
+		return origin + rounded
+	}
+
+	switch cmd.Name {
+	case "Z":
+		end = f.subpathStart
+	case "H":
+		end.X = coordinate(cmd.Args[0], f.currentPoint.X)
+	case "V":
+		end.Y = coordinate(cmd.Args[0], f.currentPoint.Y)
+	case "A":
+		for _, arg := range cmd.Args[:3] {
+			text, _ := f.number(arg)
+			tokens = append(tokens, formatToken{text: text})
+		}
+		for _, arg := range cmd.Args[3:5] {
+			text := "0"
+			if arg != 0 {
+				text = "1"
+			}
+			tokens = append(tokens, formatToken{text: text, flag: true})
+		}
+		end.X = coordinate(cmd.Args[5], f.currentPoint.X)
+		end.Y = coordinate(cmd.Args[6], f.currentPoint.Y)
+	default:
+		for i := 0; i < len(cmd.Args); i += 2 {
+			end.X = coordinate(cmd.Args[i], f.currentPoint.X)
+			end.Y = coordinate(cmd.Args[i+1], f.currentPoint.Y)
+		}
+	}
+	return tokens, end
+}
+
+// render writes a command as it would appear after everything written so
+// far.
+func (f *pathFormatter) render(name string, tokens []formatToken) string {
+	var buffer bytes.Buffer
+	if f.opts.Pretty {
+		if f.buffer.Len() > 0 {
+			buffer.WriteRune('\n')
+		}
+		buffer.WriteString(name)
+		for _, token := range tokens {
+			buffer.WriteRune(' ')
+			buffer.WriteString(token.text)
+		}
+		return buffer.String()
+	}
+
+	var last formatToken
+	if f.opts.OmitRepeatedCommands && name == f.implicitName {
+		last = f.lastToken
+	} else {
+		buffer.WriteString(name)
+	}
+	for _, token := range tokens {
+		if last.text != "" && f.needsSeparator(last, token) {
+			buffer.WriteRune(' ')
+		}
+		buffer.WriteString(token.text)
+		last = token
+	}
+	return buffer.String()
+}
+
+func (f *pathFormatter) needsSeparator(last, next formatToken) bool {
+	if f.opts.CompactArcFlags && last.flag {
+		return false
+	}
+	return next.text[0] != '-' && !(next.text[0] == '.' && strings.Contains(last.text, "."))
+}
+
+// number formats a number and returns the value that a parser would read from
+// the result.
+func (f *pathFormatter) number(value float64) (string, float64) {
+	var text string
+	if f.opts.Precision == 0 {
+		text = strconv.FormatFloat(value, 'f', -1, 64)
+	} else if f.opts.Precision < 0 {
+		text = strconv.FormatFloat(value, 'f', 0, 64)
+	} else {
+		text = strconv.FormatFloat(value, 'f', f.opts.Precision, 64)
+		text = strings.TrimRight(strings.TrimRight(text, "0"), ".")
+	}
+	if text == "-0" {
+		text = "0"
+	}
+	rounded, _ := strconv.ParseFloat(text, 64)
+	if f.opts.StripLeadingZeros {
+		if strings.HasPrefix(text, "0.") {
+			text = text[1:]
+		} else if strings.HasPrefix(text, "-0.") {
+			text = "-" + text[2:]
+		}
+	}
+	return text, rounded
+}
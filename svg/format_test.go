@@ -0,0 +1,94 @@
+package svg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPathFormat(t *testing.T) {
+	path, err := ParsePath("M10.125 20.5 L30 40 50 60 l5 5 h0.25 V-0.5 z m1 1 " +
+		"a5 5 0 0 1 10 0 c1 1 2 2 3 3 S5 5 6 6 t1 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		opts     FormatOptions
+		expected string
+	}{
+		{
+			FormatOptions{},
+			"M10.125 20.5L30 40L50 60l5 5h0.25V-0.5zm1 1a5 5 0 0 1 10 0c1 1 2 2 3 3S5 5 6 6t1 1",
+		},
+		{
+			FormatOptions{Precision: 1, Mode: FormatAbsolute},
+			"M10.1 20.5L30 40L50 60L55 65H55.2V-0.5ZM11.1 21.5A5 5 0 0 1 21.1 21.5" +
+				"C22.1 22.5 23.1 23.5 24.1 24.5S5 5 6 6T7 7",
+		},
+		{
+			FormatOptions{Precision: 2, Mode: FormatRelative, OmitRepeatedCommands: true,
+				StripLeadingZeros: true, CompactArcFlags: true},
+			"m10.12 20.5 19.88 19.5 20 20 5 5h.25v-65.5zm1.01 1a5 5 0 0110 0c1 1 2 2 3 3" +
+				"s-19.13-19.5-18.13-18.5t1 1",
+		},
+		{
+			FormatOptions{Precision: IntegerPrecision, Mode: FormatShortest,
+				OmitRepeatedCommands: true},
+			"M10 20 30 40 50 60l5 5h0V0Zm1 2a5 5 0 0 1 10 0c1 0 2 2 3 2S5 5 6 6T7 7",
+		},
+		{
+			FormatOptions{Precision: 3, Pretty: true, OmitRepeatedCommands: true},
+			"M 10.125 20.5\nL 30 40\nL 50 60\nl 5 5\nh 0.25\nV -0.5\nz\nm 1 1\n" +
+				"a 5 5 0 0 1 10 0\nc 1 1 2 2 3 3\nS 5 5 6 6\nt 1 1",
+		},
+	}
+	for i, test := range tests {
+		if actual := path.Format(test.opts); actual != test.expected {
+			t.Errorf("test %d: expected %q but got %q", i, test.expected, actual)
+		}
+	}
+}
+
+func TestPathFormatRoundTrip(t *testing.T) {
+	path, err := ParsePath("M0.5 0.25C1 2 3 4 5 6A7 8 9 1 0 10 11Q12 13 14 15Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for mode := FormatOriginal; mode <= FormatShortest; mode++ {
+		formatted := path.Format(FormatOptions{Mode: mode, OmitRepeatedCommands: true,
+			StripLeadingZeros: true, CompactArcFlags: true})
+		parsed, err := ParsePath(formatted)
+		if err != nil {
+			t.Errorf("mode %d: failed to parse %q: %s", mode, formatted, err)
+			continue
+		}
+		expected := path.Normalize()
+		actual := parsed.Normalize()
+		if len(actual) != len(expected) {
+			t.Errorf("mode %d: expected %s but got %s", mode, expected, actual)
+			continue
+		}
+		for i, cmd := range expected {
+			for j, arg := range cmd.Args {
+				if math.Abs(actual[i].Args[j]-arg) > 1e-9 {
+					t.Errorf("mode %d: expected %s but got %s", mode, expected, actual)
+				}
+			}
+		}
+	}
+}
+
+func TestPathFormatDrift(t *testing.T) {
+	path := Path{{"M", []float64{0, 0}}}
+	for i := 0; i < 10; i++ {
+		path = append(path, PathCmd{"l", []float64{0.14, 0.16}})
+	}
+	formatted := path.Format(FormatOptions{Precision: 1, Mode: FormatRelative})
+	parsed, err := ParsePath(formatted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := parsed.Normalize()[len(parsed)-1]
+	if math.Abs(last.Args[0]-1.4) > 0.05 || math.Abs(last.Args[1]-1.6) > 0.05 {
+		t.Errorf("path %q ends at %v", formatted, last.Args)
+	}
+}
@@ -0,0 +1,82 @@
+package svg
+
+import "math"
+
+// DefaultLengthTolerance is the maximum error of the Length method of curved
+// segments, relative to their size. The size of a Bezier curve is the length
+// of its control polygon, and the size of an arc is the length of a circular
+// arc with its larger radius.
+const DefaultLengthTolerance = 1e-9
+
+// maxIntegrationDepth limits how many times an interval may be bisected when
+// integrating, so that pathological curves still terminate.
+const maxIntegrationDepth = 30
+
+// These are the nodes and weights for 10-point Gauss-Legendre quadrature on
+// [-1, 1]. Each node is used along with its negation.
+var (
+	gaussLegendreNodes = []float64{0.1488743389816312, 0.4333953941292472,
+		0.6794095682990244, 0.8650633666889845, 0.9739065285171717}
+	gaussLegendreWeights = []float64{0.2955242247147529, 0.2692667193099963,
+		0.2190863625159820, 0.1494513491505806, 0.0666713443086881}
+)
+
+// float64Epsilon is the relative spacing of float64 values near 1.
+const float64Epsilon = 0x1p-52
+
+// lengthTolerance scales DefaultLengthTolerance to the size of a segment.
+func lengthTolerance(s PathSegment) float64 {
+	var size float64
+	switch s := s.(type) {
+	case *QuadraticBezier:
+		size = Line{s.Start, s.Control}.Length() + Line{s.Control, s.End}.Length()
+	case *CubicBezier:
+		size = Line{s.Start, s.Control1}.Length() + Line{s.Control1, s.Control2}.Length() +
+			Line{s.Control2, s.End}.Length()
+	case *ArcParams:
+		size = math.Abs(s.angleDelta()) * math.Pi / 180 * math.Max(s.XRadius, s.YRadius)
+	default:
+		size = Line{s.From(), s.To()}.Length()
+	}
+	return DefaultLengthTolerance * size
+}
+
+// integrate approximates the integral of f from a to b using adaptive
+// Gauss-Legendre quadrature. Intervals are bisected until the estimated error
+// in each one is below its share of the tolerance, or until that share is too
+// small to be told apart from rounding errors.
+func integrate(f func(float64) float64, a, b, tolerance float64) float64 {
+	return adaptiveIntegrate(f, a, b, gaussLegendre(f, a, b), tolerance, maxIntegrationDepth)
+}
+
+func adaptiveIntegrate(f func(float64) float64, a, b, whole, tolerance float64,
+	depth int) float64 {
+	mid := (a + b) / 2
+	left := gaussLegendre(f, a, mid)
+	right := gaussLegendre(f, mid, b)
+	if depth == 0 || math.Abs(left+right-whole) <= tolerance ||
+		tolerance <= 4*float64Epsilon*math.Abs(whole) {
+		return left + right
+	}
+	return adaptiveIntegrate(f, a, mid, left, tolerance/2, depth-1) +
+		adaptiveIntegrate(f, mid, b, right, tolerance/2, depth-1)
+}
+
+func gaussLegendre(f func(float64) float64, a, b float64) float64 {
+	halfWidth := (b - a) / 2
+	center := (a + b) / 2
+	var sum float64
+	for i, node := range gaussLegendreNodes {
+		offset := halfWidth * node
+		sum += gaussLegendreWeights[i] * (f(center-offset) + f(center+offset))
+	}
+	return sum * halfWidth
+}
+
+// speed returns a function which computes the magnitude of a derivative.
+func speed(derivative func(float64) Point) func(float64) float64 {
+	return func(t float64) float64 {
+		d := derivative(t)
+		return math.Hypot(d.X, d.Y)
+	}
+}
@@ -0,0 +1,140 @@
+package svg
+
+import "math"
+
+// A Matrix is a 2D affine transformation. It maps a point (x, y) to
+// (A*x + C*y + E, B*x + D*y + F), which matches the arguments of the
+// SVG "matrix(a b c d e f)" transform function.
+type Matrix struct {
+	A, B, C, D, E, F float64
+}
+
+// IdentityMatrix returns a matrix which leaves points unchanged.
+func IdentityMatrix() Matrix {
+	return Matrix{1, 0, 0, 1, 0, 0}
+}
+
+// TranslateMatrix returns a matrix which moves points by (tx, ty).
+func TranslateMatrix(tx, ty float64) Matrix {
+	return Matrix{1, 0, 0, 1, tx, ty}
+}
+
+// ScaleMatrix returns a matrix which scales points about the origin.
+func ScaleMatrix(sx, sy float64) Matrix {
+	return Matrix{sx, 0, 0, sy, 0, 0}
+}
+
+// RotateMatrix returns a matrix which rotates points about the origin by an
+// angle in degrees. Like in SVG, positive angles rotate clockwise when the y
+// axis points down.
+func RotateMatrix(angle float64) Matrix {
+	sin, cos := math.Sincos(angle * math.Pi / 180)
+	return Matrix{cos, sin, -sin, cos, 0, 0}
+}
+
+// SkewXMatrix returns a matrix which skews points along the x axis by an
+// angle in degrees.
+func SkewXMatrix(angle float64) Matrix {
+	return Matrix{1, 0, math.Tan(angle * math.Pi / 180), 1, 0, 0}
+}
+
+// SkewYMatrix returns a matrix which skews points along the y axis by an
+// angle in degrees.
+func SkewYMatrix(angle float64) Matrix {
+	return Matrix{1, math.Tan(angle * math.Pi / 180), 0, 1, 0, 0}
+}
+
+// Multiply returns the product m*n, which applies n and then m.
+func (m Matrix) Multiply(n Matrix) Matrix {
+	return Matrix{
+		A: m.A*n.A + m.C*n.B,
+		B: m.B*n.A + m.D*n.B,
+		C: m.A*n.C + m.C*n.D,
+		D: m.B*n.C + m.D*n.D,
+		E: m.A*n.E + m.C*n.F + m.E,
+		F: m.B*n.E + m.D*n.F + m.F,
+	}
+}
+
+// Determinant returns the determinant of the matrix's linear part. It is
+// negative when the matrix reflects points.
+func (m Matrix) Determinant() float64 {
+	return m.A*m.D - m.B*m.C
+}
+
+// Inverse returns the matrix which undoes m. If m is singular, the second
+// return value is false.
+func (m Matrix) Inverse() (Matrix, bool) {
+	det := m.Determinant()
+	if det == 0 {
+		return Matrix{}, false
+	}
+	return Matrix{
+		A: m.D / det,
+		B: -m.B / det,
+		C: -m.C / det,
+		D: m.A / det,
+		E: (m.C*m.F - m.D*m.E) / det,
+		F: (m.B*m.E - m.A*m.F) / det,
+	}, true
+}
+
+// Apply transforms a point.
+func (m Matrix) Apply(p Point) Point {
+	return Point{m.A*p.X + m.C*p.Y + m.E, m.B*p.X + m.D*p.Y + m.F}
+}
+
+// Transform applies an affine transformation to the arc. The radii and
+// rotation are recomputed to describe the transformed ellipse, and the sweep
+// flag is flipped if the transformation is a reflection.
+func (a *Arc) Transform(m Matrix) *Arc {
+	rx, ry, rotation := transformEllipse(a.XRadius, a.YRadius, a.Rotation, m)
+	return &Arc{m.Apply(a.Start), m.Apply(a.End), rx, ry, rotation, a.LargeArc,
+		a.Sweep != (m.Determinant() < 0)}
+}
+
+// Transform applies an affine transformation to the path. The result is a
+// normalized path, since commands like "H" cannot survive a rotation.
+// A path must be validated before it can be transformed.
+func (p Path) Transform(m Matrix) Path {
+	normalized := p.Normalize()
+	res := make(Path, len(normalized))
+	for i, cmd := range normalized {
+		res[i] = cmd.Clone()
+		args := res[i].Args
+		if cmd.Name == "A" {
+			args[0], args[1], args[2] = transformEllipse(args[0], args[1], args[2], m)
+			if m.Determinant() < 0 {
+				args[4] = 1 - args[4]
+			}
+			args = args[5:]
+		}
+		for j := 0; j < len(args); j += 2 {
+			point := m.Apply(Point{args[j], args[j+1]})
+			args[j], args[j+1] = point.X, point.Y
+		}
+	}
+	return res
+}
+
+// transformEllipse finds the radii and rotation of an ellipse after an affine
+// transformation. The transformed ellipse is the image of the unit circle
+// under the linear part of m times a rotation times a scale, so its radii and
+// rotation come from the singular value decomposition of that product.
+func transformEllipse(rx, ry, rotation float64, m Matrix) (newRX, newRY, newRotation float64) {
+	if rx == 0 || ry == 0 {
+		return rx, ry, rotation
+	}
+	sin, cos := math.Sincos(rotation * math.Pi / 180)
+	p := (m.A*cos + m.C*sin) * rx
+	r := (m.B*cos + m.D*sin) * rx
+	q := (m.C*cos - m.A*sin) * ry
+	s := (m.D*cos - m.B*sin) * ry
+
+	e, f := (p+s)/2, (p-s)/2
+	g, h := (r+q)/2, (r-q)/2
+	scale1, scale2 := math.Hypot(e, h), math.Hypot(f, g)
+	angle := (math.Atan2(g, f) + math.Atan2(h, e)) / 2
+
+	return scale1 + scale2, math.Abs(scale1 - scale2), angle * 180 / math.Pi
+}
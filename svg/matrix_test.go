@@ -0,0 +1,77 @@
+package svg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMatrixInverse(t *testing.T) {
+	matrices := []Matrix{
+		TranslateMatrix(10, -3).Multiply(RotateMatrix(30)),
+		ScaleMatrix(2, -0.5).Multiply(SkewXMatrix(20)).Multiply(SkewYMatrix(-10)),
+		{1, 2, 3, 4, 5, 6},
+	}
+	for i, m := range matrices {
+		inverse, ok := m.Inverse()
+		if !ok {
+			t.Error("matrix", i, "should be invertible")
+			continue
+		}
+		for _, product := range []Matrix{m.Multiply(inverse), inverse.Multiply(m)} {
+			if !product.approxEqual(IdentityMatrix()) {
+				t.Error("expected identity for matrix", i, "but got", product)
+			}
+		}
+	}
+	if _, ok := (Matrix{1, 2, 2, 4, 0, 0}).Inverse(); ok {
+		t.Error("singular matrix should not be invertible")
+	}
+
+	m := TranslateMatrix(10, 0).Multiply(ScaleMatrix(2, 3))
+	if p := m.Apply(Point{1, 1}); !p.approxEqual(Point{12, 3}) {
+		t.Error("expected scale then translation but got", p)
+	}
+}
+
+func TestPathTransform(t *testing.T) {
+	path, err := ParsePath(`M10,10 h20 v20 q10,10 20,0 c10,0 10,10 0,20 z
+		M50,50 a20,10 30 1 1 20,10 A10,10 0 0 0 90,90 a5,1 0 0 1 -30,0`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	matrices := []Matrix{
+		TranslateMatrix(5, -7),
+		RotateMatrix(73).Multiply(ScaleMatrix(1.5, 0.5)),
+		ScaleMatrix(-1, 1).Multiply(TranslateMatrix(3, 4)),
+		SkewXMatrix(35).Multiply(RotateMatrix(-20)),
+	}
+	original := path.Segments()
+	for i, m := range matrices {
+		transformed := path.Transform(m).Segments()
+		if len(transformed) != len(original) {
+			t.Fatal("matrix", i, "changed the number of segments")
+		}
+		for j, segment := range original {
+			for _, frac := range []float64{0, 0.25, 0.5, 0.75, 1} {
+				point := m.Apply(segment.Evaluate(frac))
+				if d := distanceToSegment(point, transformed[j]); d > 1e-2 {
+					t.Error("matrix", i, "segment", j, "is off by", d, "at", frac)
+				}
+			}
+		}
+	}
+}
+
+func (m Matrix) approxEqual(m1 Matrix) bool {
+	return math.Abs(m.A-m1.A) < 1e-8 && math.Abs(m.B-m1.B) < 1e-8 &&
+		math.Abs(m.C-m1.C) < 1e-8 && math.Abs(m.D-m1.D) < 1e-8 &&
+		math.Abs(m.E-m1.E) < 1e-8 && math.Abs(m.F-m1.F) < 1e-8
+}
+
+func distanceToSegment(p Point, s PathSegment) float64 {
+	dist := math.Inf(1)
+	for t := 0.0; t <= 1; t += 1e-4 {
+		dist = math.Min(dist, Line{p, s.Evaluate(t)}.Length())
+	}
+	return dist
+}
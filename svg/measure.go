@@ -0,0 +1,151 @@
+package svg
+
+import "math"
+
+// maxInverseLengthIterations limits the iterations used to find the
+// parameter at which a segment reaches a given length.
+const maxInverseLengthIterations = 50
+
+// A PathPosition describes a point at some distance along a path.
+type PathPosition struct {
+	Point Point
+
+	// Angle is the direction of the path at Point, in degrees. It is 0 when
+	// the path heads along the positive x axis and 90 when it heads along the
+	// positive y axis.
+	Angle float64
+
+	// Segment is the index of the segment containing Point, in the list from
+	// Path.Segments, and T is the parameter of Point within that segment.
+	Segment int
+	T       float64
+}
+
+// A PathMeasure maps distances along a path to points on it and back. It
+// measures the path's segments once, so it is cheaper than calling
+// Path.PointAtLength several times on the same path.
+type PathMeasure struct {
+	segments []PathSegment
+
+	// offsets[i] is the distance at which segment i begins. The last entry is
+	// the length of the whole path.
+	offsets []float64
+}
+
+// NewPathMeasure measures a path. The path must be valid.
+func NewPathMeasure(p Path) *PathMeasure {
+	return newSegmentMeasure(p.Segments())
+}
+
+func newSegmentMeasure(segments []PathSegment) *PathMeasure {
+	offsets := make([]float64, len(segments)+1)
+	for i, segment := range segments {
+		offsets[i+1] = offsets[i] + segment.Length()
+	}
+	return &PathMeasure{segments, offsets}
+}
+
+// Length returns the length of the path. Moves between subpaths do not count
+// towards it.
+func (m *PathMeasure) Length() float64 {
+	return m.offsets[len(m.offsets)-1]
+}
+
+// Segments returns the path's segments, which PathPosition.Segment indexes.
+func (m *PathMeasure) Segments() []PathSegment {
+	return m.segments
+}
+
+// PointAtLength finds the point at a distance along the path, like
+// SVGPathElement.getPointAtLength. Distances outside of the path are clamped
+// to its ends. If the path has no segments, the result's Segment is -1.
+func (m *PathMeasure) PointAtLength(distance float64) PathPosition {
+	if len(m.segments) == 0 {
+		return PathPosition{Segment: -1}
+	}
+	distance = math.Max(0, math.Min(distance, m.Length()))
+
+	// Find the last segment which starts at or before the distance.
+	lo, hi := 0, len(m.segments)-1
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if m.offsets[mid] <= distance {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+
+	segment := m.segments[lo]
+	t := parameterAtLength(segment, distance-m.offsets[lo])
+	direction := Tangent(segment, t)
+	return PathPosition{
+		Point:   segment.Evaluate(t),
+		Angle:   math.Atan2(direction.Y, direction.X) * 180 / math.Pi,
+		Segment: lo,
+		T:       t,
+	}
+}
+
+// LengthAt finds the distance along the path of the point with parameter t
+// in a segment. It is the inverse of PointAtLength.
+func (m *PathMeasure) LengthAt(segment int, t float64) float64 {
+	return m.offsets[segment] + partialLength(m.segments[segment], t)
+}
+
+// PointAtLength finds the point at a distance along the path. See
+// PathMeasure.PointAtLength for details. The path must be valid.
+func (p Path) PointAtLength(distance float64) PathPosition {
+	return NewPathMeasure(p).PointAtLength(distance)
+}
+
+// partialLength measures a segment from its start to parameter t.
+func partialLength(s PathSegment, t float64) float64 {
+	switch s := s.(type) {
+	case Line:
+		return s.Length() * t
+	case *ArcParams:
+		if s.XRadius == s.YRadius {
+			return s.Length() * t
+		}
+	}
+	return integrate(speed(s.Derivative), 0, t, lengthTolerance(s))
+}
+
+// parameterAtLength finds the parameter at which a segment reaches a length.
+// It uses Newton's method, falling back on bisection when a step would leave
+// the interval known to contain the solution.
+func parameterAtLength(s PathSegment, length float64) float64 {
+	total := s.Length()
+	if total == 0 || length <= 0 {
+		return 0
+	} else if length >= total {
+		return 1
+	}
+	if _, ok := s.(Line); ok {
+		return length / total
+	} else if arc, ok := s.(*ArcParams); ok && arc.XRadius == arc.YRadius {
+		return length / total
+	}
+
+	segmentSpeed := speed(s.Derivative)
+	lo, hi := 0.0, 1.0
+	t := length / total
+	for i := 0; i < maxInverseLengthIterations; i++ {
+		diff := partialLength(s, t) - length
+		if math.Abs(diff) < 1e-9 {
+			break
+		}
+		if diff > 0 {
+			hi = t
+		} else {
+			lo = t
+		}
+		next := t - diff/segmentSpeed(t)
+		if math.IsNaN(next) || next <= lo || next >= hi {
+			next = (lo + hi) / 2
+		}
+		t = next
+	}
+	return t
+}
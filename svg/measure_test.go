@@ -0,0 +1,59 @@
+package svg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPointAtLength(t *testing.T) {
+	path, err := ParsePath("M0 0 h10 a5 5 0 0 1 0 10 M20 20 v-10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	halfCircle := 5 * math.Pi
+	cases := []struct {
+		distance float64
+		expected PathPosition
+	}{
+		{-1, PathPosition{Point{0, 0}, 0, 0, 0}},
+		{5, PathPosition{Point{5, 0}, 0, 0, 0.5}},
+		{10 + halfCircle/2, PathPosition{Point{15, 5}, 90, 1, 0.5}},
+		{10 + halfCircle + 2.5, PathPosition{Point{20, 17.5}, -90, 2, 0.25}},
+		{100, PathPosition{Point{20, 10}, -90, 2, 1}},
+	}
+	for _, c := range cases {
+		actual := path.PointAtLength(c.distance)
+		if !actual.Point.approxEqual(c.expected.Point) ||
+			math.Abs(actual.Angle-c.expected.Angle) > 1e-6 ||
+			actual.Segment != c.expected.Segment || math.Abs(actual.T-c.expected.T) > 1e-6 {
+			t.Error("expected", c.expected, "at", c.distance, "but got", actual)
+		}
+	}
+
+	if pos := (Path{}).PointAtLength(1); pos.Segment != -1 {
+		t.Error("empty path should have no segment, but got", pos)
+	}
+}
+
+func TestLengthAt(t *testing.T) {
+	path, err := ParsePath(`M10,50 C40,10 70,90 100,50 Q120,0 130,50
+		A20,10 30 0 1 150,60 L200,60 C200,60 250,60 250,100`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	measure := NewPathMeasure(path)
+	for distance := 0.0; distance <= measure.Length(); distance += measure.Length() / 37 {
+		pos := measure.PointAtLength(distance)
+		if actual := measure.LengthAt(pos.Segment, pos.T); math.Abs(actual-distance) > 1e-6 {
+			t.Error("expected length", distance, "but got", actual)
+		}
+		segment := measure.Segments()[pos.Segment]
+		if !segment.Evaluate(pos.T).approxEqual(pos.Point) {
+			t.Error("point at", distance, "does not match its segment and parameter")
+		}
+	}
+	start := measure.PointAtLength(measure.LengthAt(4, 0))
+	if math.Abs(start.Angle) > 1e-9 {
+		t.Error("repeated control point should not change the angle, but got", start.Angle)
+	}
+}
@@ -0,0 +1,288 @@
+package svg
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// maxOffsetDepth limits how many times a curve may be subdivided when it
+	// is offset, so that tiny tolerances still terminate.
+	maxOffsetDepth = 10
+
+	// offsetSamples is the number of intervals at which an offset curve is
+	// compared to the curve it approximates.
+	offsetSamples = 16
+
+	// offsetCuspSamples is the number of intervals searched for cusps of an
+	// offset curve.
+	offsetCuspSamples = 64
+)
+
+// Offset computes a path which is parallel to the path at a signed distance,
+// in the direction of its segments' Normals. Each segment is offset with its
+// Offset method, and the pieces are joined with circular arcs around outer
+// corners and with lines across inner corners. Closed subpaths stay closed,
+// and subpaths with no length are left out. If the tolerance is not positive,
+// DefaultStrokeTolerance is used, like in Stroke.
+//
+// The result is the raw offset of the path, so it crosses itself near inner
+// corners and wherever the path curves more sharply than the distance
+// allows. In particular, offsetting a closed path inwards leaves loops at its
+// corners, which must be cleaned up with Boolean. For example, a closed path
+// shrunk by d is Difference(p, Stroke(p, StrokeOptions{Width: 2 * d, Join:
+// RoundJoin})). A path must be validated before it can be offset.
+func (p Path) Offset(distance, tolerance float64) Path {
+	if tolerance <= 0 {
+		tolerance = DefaultStrokeTolerance
+	}
+	s := &stroker{opts: StrokeOptions{Join: RoundJoin, Tolerance: tolerance},
+		distance: distance, offsetting: true, res: Path{}}
+	for _, subpath := range p.Subpaths() {
+		segments := nonDegenerateSegments(subpath.Segments)
+		if len(segments) == 0 {
+			continue
+		}
+		s.moveTo(s.offset(segments[0], 0))
+		s.side(segments, subpath.Closed)
+		if subpath.Closed {
+			s.close()
+		}
+	}
+	return s.res
+}
+
+// segmentOffset calls the Offset method of any segment.
+func segmentOffset(s PathSegment, distance, tolerance float64) []PathSegment {
+	switch s := s.(type) {
+	case Line:
+		return s.Offset(distance, tolerance)
+	case *QuadraticBezier:
+		return s.Offset(distance, tolerance)
+	case *CubicBezier:
+		return s.Offset(distance, tolerance)
+	case *ArcParams:
+		return s.Offset(distance, tolerance)
+	}
+	panic("unsupported segment type")
+}
+
+// Offset returns the line moved by a signed distance in the direction of its
+// Normal. The tolerance is not needed, since the result is exact.
+func (l Line) Offset(distance, tolerance float64) []PathSegment {
+	n := Normal(l, 0)
+	return []PathSegment{Line{offsetPoint(l.Start, n, distance),
+		offsetPoint(l.End, n, distance)}}
+}
+
+// Offset approximates the curve at a signed distance from this one, in the
+// direction of its Normal, with connected segments. See CubicBezier.Offset
+// for details.
+func (q *QuadraticBezier) Offset(distance, tolerance float64) []PathSegment {
+	return q.ToCubic().Offset(distance, tolerance)
+}
+
+// Offset approximates the curve at a signed distance from this one, in the
+// direction of its Normal, with connected segments which are within the
+// tolerance of it.
+//
+// The curve is split at its inflections, at its cusps, including the points
+// where a straight curve turns back on itself, and at the points where the
+// offset curve has cusps because the distance matches the radius of
+// curvature. Each piece is fit with a cubic curve which starts and ends on
+// the offset curve, in the directions of its tangents there, and passes
+// through its middle. Pieces which do not fit well enough are split in half.
+// The pieces on either side of a cusp of this curve are joined with a half
+// circle around the cusp.
+func (c *CubicBezier) Offset(distance, tolerance float64) []PathSegment {
+	if distance == 0 {
+		res := *c
+		return []PathSegment{&res}
+	}
+	splits := c.Inflections()
+	if t, ok := c.Cusp(); ok && t > 0 && t < 1 {
+		splits = append(splits, t)
+	}
+	splits = append(splits, c.lineTurns()...)
+	splits = append(splits, offsetCusps(c, distance)...)
+	return offsetCurve(c, splits, distance, tolerance)
+}
+
+// offsetCurve approximates the offset of a curve, which is split at the given
+// parameters first. Where the offsets of neighboring pieces do not meet, they
+// are joined with a half circle.
+func offsetCurve(s PathSegment, splits []float64, distance, tolerance float64) []PathSegment {
+	splits = append(append([]float64{0}, splits...), 1)
+	sort.Float64s(splits)
+
+	var res []PathSegment
+	for i := 1; i < len(splits); i++ {
+		t0, t1 := splits[i-1], splits[i]
+		if t1-t0 < 1e-9 {
+			continue
+		}
+		piece := subsegment(s, t0, t1)
+		if degenerateSegment(piece) {
+			continue
+		}
+		start := offsetPoint(piece.From(), Normal(piece, 0), distance)
+		if len(res) > 0 {
+			if end := res[len(res)-1].To(); !end.approxEqual(start) {
+				cusp := &Arc{end, start, math.Abs(distance), math.Abs(distance), 0, false,
+					distance < 0}
+				if params, _ := cusp.Params(); params != nil {
+					res = append(res, params)
+				}
+			}
+		}
+		res = offsetPiece(piece, distance, tolerance, 0, res)
+	}
+	return res
+}
+
+// lineTurns finds the parameters strictly between 0 and 1 at which a CubicLine
+// curve turns back on itself, which are cusps as far as offsetting goes.
+func (c *CubicBezier) lineTurns() []float64 {
+	if c.Classify() != CubicLine {
+		return nil
+	}
+	a, b, d := c.powerBasis()
+	direction := a
+	for _, v := range []Point{b, d} {
+		if norm(v) > norm(direction) {
+			direction = v
+		}
+	}
+	var res []float64
+	for _, t := range quadraticRoots(dot(d, direction), 2*dot(b, direction),
+		dot(a, direction)) {
+		if t > 0 && t < 1 {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// offsetCusps finds the parameters strictly between 0 and 1 at which a
+// segment's curvature times the distance crosses 1.
+func offsetCusps(s PathSegment, distance float64) []float64 {
+	f := func(t float64) float64 {
+		return 1 - distance*Curvature(s, t)
+	}
+	var res []float64
+	last := f(0)
+	for i := 1; i <= offsetCuspSamples; i++ {
+		lo := float64(i-1) / offsetCuspSamples
+		t := float64(i) / offsetCuspSamples
+		value := f(t)
+		if !math.IsNaN(last) && !math.IsNaN(value) && !math.IsInf(last, 0) &&
+			!math.IsInf(value, 0) && (last < 0) != (value < 0) {
+			if root := bisectRoot(f, lo, t, last); root > 0 && root < 1 {
+				res = append(res, root)
+			}
+		}
+		last = value
+	}
+	return res
+}
+
+// offsetPiece approximates the offset of a curve which has no inflections or
+// cusps, and whose offset has no cusps, appending cubic curves to res.
+func offsetPiece(c PathSegment, distance, tolerance float64, depth int,
+	res []PathSegment) []PathSegment {
+	start := offsetPoint(c.From(), Normal(c, 0), distance)
+	end := offsetPoint(c.To(), Normal(c, 1), distance)
+	mid := offsetPoint(c.Evaluate(0.5), Normal(c, 0.5), distance)
+
+	// Where the offset is further from the center of curvature than the
+	// distance, it runs backwards.
+	direction := 1.0
+	if distance*Curvature(c, 0.5) > 1 {
+		direction = -1
+	}
+	t0 := Tangent(c, 0)
+	t1 := Tangent(c, 1)
+	t0 = Point{direction * t0.X, direction * t0.Y}
+	t1 = Point{direction * t1.X, direction * t1.Y}
+
+	// A cubic curve's middle is (start + 3*control1 + 3*control2 + end)/8, so
+	// the lengths of the handles which make it pass through mid solve
+	// a*t0 - b*t1 = (8*mid - 4*(start + end))/3.
+	rhs := Point{(8*mid.X - 4*(start.X+end.X)) / 3, (8*mid.Y - 4*(start.Y+end.Y)) / 3}
+	det := cross(t1, t0)
+	var a, b float64
+	if math.Abs(det) > 1e-9 {
+		a = cross(t1, rhs) / det
+		b = cross(t0, rhs) / det
+	}
+	if !(math.Abs(det) > 1e-9 && a >= 0 && b >= 0) {
+		a = Line{start, end}.Length() / 3
+		b = a
+	}
+	fit := &CubicBezier{start, offsetPoint(start, t0, a), offsetPoint(end, t1, -b), end}
+
+	if depth < maxOffsetDepth && offsetError(c, fit, distance) > tolerance {
+		res = offsetPiece(subsegment(c, 0, 0.5), distance, tolerance, depth+1, res)
+		return offsetPiece(subsegment(c, 0.5, 1), distance, tolerance, depth+1, res)
+	}
+	return append(res, fit)
+}
+
+// offsetError estimates how far a curve is from the offset of c, by finding
+// the closest points on the curve to several points on the offset.
+func offsetError(c PathSegment, fit *CubicBezier, distance float64) float64 {
+	var maxError float64
+	for i := 1; i < offsetSamples; i++ {
+		t := float64(i) / offsetSamples
+		target := offsetPoint(c.Evaluate(t), Normal(c, t), distance)
+		s := closestParameter(fit, target, t)
+		maxError = math.Max(maxError, Line{fit.Evaluate(s), target}.Length())
+	}
+	return maxError
+}
+
+// closestParameter finds the parameter of the point on a segment which is
+// closest to a target, using Newton's method starting from a guess.
+func closestParameter(s PathSegment, target Point, guess float64) float64 {
+	t := guess
+	for i := 0; i < 8; i++ {
+		p := s.Evaluate(t)
+		diff := Point{p.X - target.X, p.Y - target.Y}
+		d1 := s.Derivative(t)
+		d2 := s.SecondDerivative(t)
+		slope := dot(d1, d1) + dot(diff, d2)
+		if slope <= 0 {
+			break
+		}
+		t = math.Max(0, math.Min(1, t-dot(diff, d1)/slope))
+	}
+	return t
+}
+
+// Offset approximates the arc at a signed distance from this one, in the
+// direction of its Normal, with connected segments which are within the
+// tolerance of it. The offset of a circular arc is another circular arc, or
+// nothing if the distance is its radius. The offsets of elliptical arcs are
+// approximated with cubic curves, like in CubicBezier.Offset.
+func (a *ArcParams) Offset(distance, tolerance float64) []PathSegment {
+	if a.XRadius != a.YRadius {
+		return offsetCurve(a, offsetCusps(a, distance), distance, tolerance)
+	}
+
+	// The Normal of an arc points towards its center when its angle
+	// increases, and away from it otherwise.
+	radius := a.XRadius + distance
+	if a.Sweep {
+		radius = a.XRadius - distance
+	}
+	if radius == 0 {
+		return []PathSegment{}
+	}
+	res := *a
+	res.XRadius, res.YRadius = math.Abs(radius), math.Abs(radius)
+	if radius < 0 {
+		res.StartAngle = clipDegreesTo360(res.StartAngle + 180)
+		res.EndAngle = clipDegreesTo360(res.EndAngle + 180)
+	}
+	return []PathSegment{&res}
+}
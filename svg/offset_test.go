@@ -0,0 +1,141 @@
+package svg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLineOffset(t *testing.T) {
+	offset := Line{Point{0, 0}, Point{10, 0}}.Offset(2, 1e-3)
+	if len(offset) != 1 || offset[0] != (Line{Point{0, 2}, Point{10, 2}}) {
+		t.Error("unexpected offset:", offset)
+	}
+}
+
+func TestArcOffset(t *testing.T) {
+	arcs := []*Arc{
+		{Point{10, 0}, Point{0, 10}, 10, 10, 0, false, true},
+		{Point{10, 0}, Point{0, 10}, 10, 10, 0, true, false},
+		{Point{10, 0}, Point{0, 5}, 10, 5, 30, true, true},
+	}
+	for i, arc := range arcs {
+		params, _ := arc.Params()
+		for _, distance := range []float64{-3, 3, 15} {
+			offset := params.Offset(distance, 1e-3)
+			checkOffset(t, params, offset, distance, 1e-3)
+			if arc.XRadius == arc.YRadius && len(offset) != 1 {
+				t.Error("arc", i, "should have a circular offset but got", offset)
+			}
+		}
+	}
+	params, _ := arcs[0].Params()
+	if offset := params.Offset(10, 1e-3); len(offset) != 0 {
+		t.Error("expected an empty offset but got", offset)
+	}
+}
+
+func TestCubicOffset(t *testing.T) {
+	cubics := []*CubicBezier{
+		{Point{0, 0}, Point{10, 10}, Point{20, -10}, Point{30, 0}},
+		{Point{0, 0}, Point{10, 10}, Point{-5, 10}, Point{5, 0}},
+		{Point{0, 0}, Point{10, 10}, Point{0, 10}, Point{10, 0}},
+		{Point{0, 0}, Point{0, 10}, Point{10, 10}, Point{10, 0}},
+		{Point{0, 0}, Point{50, 0}, Point{-20, 0}, Point{30, 0}},
+		{Point{0, 0}, Point{100, 0}, Point{100, 100}, Point{0, 100}},
+	}
+	for _, c := range cubics {
+		for _, distance := range []float64{-4, -1, 1, 4} {
+			checkOffset(t, c, c.Offset(distance, 1e-2), distance, 1e-2)
+		}
+	}
+
+	q := &QuadraticBezier{Point{0, 0}, Point{10, 10}, Point{20, 0}}
+	checkOffset(t, q, q.Offset(2, 1e-3), 2, 1e-3)
+}
+
+func TestPathOffset(t *testing.T) {
+	path, _ := ParsePath("M0 0H10")
+	if actual := path.Offset(2, 1e-3).String(); actual != "M0 2L10 2" {
+		t.Error("unexpected offset:", actual)
+	}
+
+	// Outer corners are rounded.
+	path, _ = ParsePath("M5 5H15V15H5Z")
+	offset := path.Offset(-1, 1e-3)
+	if len(offset.Subpaths()) != 1 || !offset.Subpaths()[0].Closed {
+		t.Fatal("expected a single closed subpath:", offset)
+	}
+	if actual, expected := strokeArea(offset), 140+math.Pi; math.Abs(actual-expected) > 0.05 {
+		t.Error("expected area", expected, "but got", actual)
+	}
+
+	// Offsetting the other way gives a circle on the other side.
+	path, _ = NewPathBuilder().Circle(10, 10, 5).Path()
+	radii := map[float64]bool{}
+	for _, distance := range []float64{-2, 2} {
+		points := path.Offset(distance, 1e-3).Flatten(1e-3)[0]
+		radius := (Line{Point{10, 10}, points[0]}).Length()
+		radii[math.Round(radius)] = true
+		for _, p := range points {
+			if d := (Line{Point{10, 10}, p}).Length(); math.Abs(d-radius) > 1e-3 {
+				t.Fatal("distance", distance, "gave point", p, "at", d, "from the center")
+			}
+		}
+	}
+	if !radii[3] || !radii[7] {
+		t.Error("unexpected radii:", radii)
+	}
+
+	// A tolerance of 0 uses the default instead of subdividing as far as
+	// possible.
+	if a, b := len(path.Offset(2, 0)), len(path.Offset(2, DefaultStrokeTolerance)); a != b {
+		t.Error("expected", b, "commands with the default tolerance but got", a)
+	}
+
+	// Shrinking a square leaves loops at its corners, which Boolean removes.
+	path, _ = ParsePath("M0 0H10V10H0Z")
+	if offset := path.Offset(2, 0).String(); offset != "M0 2L10 2L8 0L8 10L10 8L0 8L2 10L2 0L0 2Z" {
+		t.Error("unexpected offset:", offset)
+	}
+	shrunk := Difference(path, Stroke(path, StrokeOptions{Width: 4, Join: RoundJoin}))
+	if shrunk.String() != "M2 2L8 2L8 8L2 8Z" {
+		t.Error("unexpected shrunk square:", shrunk)
+	}
+}
+
+// checkOffset checks that segments are connected, that they start and end
+// where the offset of a segment does, and that they are within a tolerance
+// of it.
+func checkOffset(t *testing.T, s PathSegment, offset []PathSegment, distance,
+	tolerance float64) {
+	t.Helper()
+	trueOffset := func(frac float64) Point {
+		return offsetPoint(s.Evaluate(frac), Normal(s, frac), distance)
+	}
+	if len(offset) == 0 {
+		t.Error("empty offset of", s)
+		return
+	}
+	if !offset[0].From().approxEqual(trueOffset(0)) ||
+		!offset[len(offset)-1].To().approxEqual(trueOffset(1)) {
+		t.Error("offset of", s, "by", distance, "has the wrong endpoints")
+	}
+	var points []Point
+	for i, segment := range offset {
+		if i > 0 && !segment.From().approxEqual(offset[i-1].To()) {
+			t.Error("offset of", s, "by", distance, "is disconnected")
+		}
+		points = append(points, segment.Flatten(tolerance/10)...)
+	}
+
+	// Cusps of the segment are joined with half circles, which are not part of
+	// its offset curve, so only the distance from the offset curve to the
+	// result is checked.
+	for i := 0; i <= 200; i++ {
+		p := trueOffset(float64(i) / 200)
+		if d := polylineDistance(p, points); d > tolerance*1.2 {
+			t.Errorf("offset of %v by %f is %f from %v", s, distance, d, p)
+			return
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package svg
+
+import "math"
+
+// Optimize rewrites a path with fewer and shorter commands, like the path
+// plugin of svgo. It may move the rendered outline, but only by roughly the
+// tolerance. A path must be validated before it can be optimized.
+//
+// Segments which are shorter than the tolerance are removed, curves which
+// are close to lines or quadratic curves are replaced by them, and runs of
+// collinear lines are merged. A line back to the start of a subpath is left
+// to the closepath command, and movetos which are followed by another moveto
+// are removed. Finally, commands are replaced by the shorthands "H", "V", "S"
+// and "T" wherever possible. The result only uses absolute commands, so it
+// is best written with Path.Format using FormatShortest.
+func (p Path) Optimize(tolerance float64) Path {
+	normalized := p.Normalize()
+	o := &pathOptimizer{tolerance: tolerance, res: Path{}}
+
+	// Unlike the current point of the optimizer, this is where the original
+	// path is.
+	var start Point
+
+	for i, cmd := range normalized {
+		switch cmd.Name {
+		case "M":
+			if i+1 == len(normalized) || normalized[i+1].Name == "M" {
+				continue
+			}
+			o.currentPoint = Point{cmd.Args[0], cmd.Args[1]}
+			o.subpathStart = o.currentPoint
+			o.append(cmd.Clone())
+		case "Z":
+			if o.lineStart != nil && o.distance(o.subpathStart) <= tolerance {
+				o.res = o.res[:len(o.res)-1]
+			}
+			o.currentPoint = o.subpathStart
+			o.append(cmd.Clone())
+		case "L":
+			o.lineTo(Point{cmd.Args[0], cmd.Args[1]})
+		case "Q":
+			o.quadTo(Point{cmd.Args[0], cmd.Args[1]}, Point{cmd.Args[2], cmd.Args[3]})
+		case "C":
+			o.cubicTo(Point{cmd.Args[0], cmd.Args[1]}, Point{cmd.Args[2], cmd.Args[3]},
+				Point{cmd.Args[4], cmd.Args[5]})
+		case "A":
+			o.arcTo(start, cmd)
+		}
+		if cmd.Name == "Z" {
+			start = o.subpathStart
+		} else {
+			start = Point{cmd.Args[len(cmd.Args)-2], cmd.Args[len(cmd.Args)-1]}
+		}
+	}
+	return useShorthands(o.res, tolerance)
+}
+
+// A pathOptimizer builds an optimized path. The current point is where the
+// optimized path is, which may be up to the tolerance away from where the
+// original path is.
+type pathOptimizer struct {
+	tolerance    float64
+	res          Path
+	currentPoint Point
+	subpathStart Point
+
+	// If the last command is a line, lineStart is where it starts and
+	// linePoints are the ends of the lines merged into it.
+	lineStart  *Point
+	linePoints []Point
+}
+
+func (o *pathOptimizer) append(cmd PathCmd) {
+	o.res = append(o.res, cmd)
+	o.lineStart = nil
+	o.linePoints = nil
+}
+
+// distance computes the distance from the current point to p.
+func (o *pathOptimizer) distance(p Point) float64 {
+	return Line{o.currentPoint, p}.Length()
+}
+
+func (o *pathOptimizer) lineTo(end Point) {
+	if o.distance(end) <= o.tolerance {
+		return
+	}
+	o.forceLineTo(end)
+}
+
+// forceLineTo adds a line, even if it is short enough to leave out.
+func (o *pathOptimizer) forceLineTo(end Point) {
+	if o.lineStart != nil {
+		points := append(o.linePoints, o.currentPoint)
+		merge := true
+		for _, point := range points {
+			if lineDistance(point, *o.lineStart, end) > o.tolerance {
+				merge = false
+				break
+			}
+		}
+		if merge {
+			o.res[len(o.res)-1] = PathCmd{"L", []float64{end.X, end.Y}}
+			o.linePoints = points
+			o.currentPoint = end
+			return
+		}
+	}
+	start := o.currentPoint
+	o.append(PathCmd{"L", []float64{end.X, end.Y}})
+	o.lineStart = &start
+	o.currentPoint = end
+}
+
+func (o *pathOptimizer) quadTo(control, end Point) {
+	if lineDistance(control, o.currentPoint, end) <= o.tolerance {
+		o.lineTo(end)
+		return
+	}
+	o.append(PathCmd{"Q", []float64{control.X, control.Y, end.X, end.Y}})
+	o.currentPoint = end
+}
+
+func (o *pathOptimizer) cubicTo(control1, control2, end Point) {
+	start := o.currentPoint
+	if lineDistance(control1, start, end) <= o.tolerance &&
+		lineDistance(control2, start, end) <= o.tolerance {
+		o.lineTo(end)
+		return
+	}
+	c := &CubicBezier{start, control1, control2, end}
+	if control, maxError := c.quadraticApproximation(); maxError <= o.tolerance {
+		o.quadTo(control, end)
+		return
+	}
+	o.append(PathCmd{"C", []float64{control1.X, control1.Y, control2.X, control2.Y,
+		end.X, end.Y}})
+	o.currentPoint = end
+}
+
+// arcTo adds an arc which starts at the point start in the original path.
+func (o *pathOptimizer) arcTo(start Point, cmd PathCmd) {
+	end := Point{cmd.Args[5], cmd.Args[6]}
+	if end == start {
+		// An arc to its own start is not drawn.
+		return
+	}
+	arc := &Arc{start, end, cmd.Args[0], cmd.Args[1], cmd.Args[2],
+		cmd.Args[3] != 0, cmd.Args[4] != 0}
+	params, line := arc.Params()
+	if line != nil {
+		o.lineTo(end)
+		return
+	}
+	bounds := params.Bounds()
+	if o.distance(bounds.Min) <= o.tolerance && o.distance(bounds.Max) <= o.tolerance {
+		return
+	}
+
+	// Even a tiny change to its start can change an arc's center a lot, so
+	// arcs must start where they did originally.
+	if o.currentPoint != start {
+		o.forceLineTo(start)
+	}
+	o.append(cmd.Clone())
+	o.currentPoint = end
+}
+
+// useShorthands replaces the commands of an absolute, normalized path with
+// "H", "V", "S" and "T" where they would draw the same thing. Control points
+// may move by up to the tolerance.
+func useShorthands(p Path, tolerance float64) Path {
+	res := make(Path, len(p))
+	currentPoint := Point{0, 0}
+	subpathStart := Point{0, 0}
+
+	// This is the control point which "S" or "T" would reflect, as a parser
+	// would compute it.
+	var lastControl Point
+	lastName := ""
+
+	for i, cmd := range p {
+		res[i] = cmd
+		reflected := Point{2*currentPoint.X - lastControl.X, 2*currentPoint.Y - lastControl.Y}
+		switch cmd.Name {
+		case "L":
+			// Lines are only changed when it is exact, since moving the end of a
+			// line would also move the start of an arc after it.
+			if cmd.Args[1] == currentPoint.Y {
+				res[i] = PathCmd{"H", []float64{cmd.Args[0]}}
+			} else if cmd.Args[0] == currentPoint.X {
+				res[i] = PathCmd{"V", []float64{cmd.Args[1]}}
+			}
+		case "C":
+			if lastName != "C" {
+				reflected = currentPoint
+			}
+			if (Line{reflected, Point{cmd.Args[0], cmd.Args[1]}}).Length() <= tolerance {
+				res[i] = PathCmd{"S", append([]float64{}, cmd.Args[2:]...)}
+			}
+			lastControl = Point{cmd.Args[2], cmd.Args[3]}
+		case "Q":
+			if lastName != "Q" {
+				reflected = currentPoint
+			}
+			if (Line{reflected, Point{cmd.Args[0], cmd.Args[1]}}).Length() <= tolerance {
+				res[i] = PathCmd{"T", append([]float64{}, cmd.Args[2:]...)}
+				lastControl = reflected
+			} else {
+				lastControl = Point{cmd.Args[0], cmd.Args[1]}
+			}
+		}
+		lastName = cmd.Name
+
+		switch res[i].Name {
+		case "M":
+			subpathStart = Point{cmd.Args[0], cmd.Args[1]}
+			currentPoint = subpathStart
+		case "Z":
+			currentPoint = subpathStart
+		case "H":
+			currentPoint.X = cmd.Args[0]
+		case "V":
+			currentPoint.Y = cmd.Args[1]
+		default:
+			currentPoint = Point{cmd.Args[len(cmd.Args)-2], cmd.Args[len(cmd.Args)-1]}
+		}
+	}
+	return res
+}
+
+// quadraticApproximation finds the quadratic curve which is closest to the
+// cubic curve, returning its control point and the maximum distance between
+// the two curves.
+func (c *CubicBezier) quadraticApproximation() (Point, float64) {
+	control := Point{
+		(3*(c.Control1.X+c.Control2.X) - c.Start.X - c.End.X) / 4,
+		(3*(c.Control1.Y+c.Control2.Y) - c.Start.Y - c.End.Y) / 4,
+	}
+	_, _, d := c.powerBasis()
+	return control, math.Sqrt(3) / 36 * norm(d)
+}
+
+// lineDistance computes the distance from a point to the line segment between
+// start and end.
+func lineDistance(p, start, end Point) float64 {
+	direction := Point{end.X - start.X, end.Y - start.Y}
+	offset := Point{p.X - start.X, p.Y - start.Y}
+	lengthSquared := dot(direction, direction)
+	if lengthSquared == 0 {
+		return norm(offset)
+	}
+	t := math.Max(0, math.Min(1, dot(offset, direction)/lengthSquared))
+	return Line{p, Point{start.X + t*direction.X, start.Y + t*direction.Y}}.Length()
+}
@@ -0,0 +1,60 @@
+package svg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPathOptimize(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"M0 0L10 0L10 0L20 0L20 10L20 20L0 20L0 0Z", "M0 0H20V20H0Z"},
+		{"M0 0M1 1L5 5M3 3", "M1 1L5 5"},
+		{"M0 0L10 0L5 0", "M0 0H10H5"},
+		{"M0 0C0 0 10 10 10 10", "M0 0L10 10"},
+		{"M0 0C2 4 4 4 6 0", "M0 0Q3 6 6 0"},
+		{"M0 0Q5 0.0001 10 0", "M0 0H10"},
+		{"M0 0C1 2 3 4 5 6C7 8 9 7 10 0", "M0 0C1 2 3 4 5 6S9 7 10 0"},
+		{"M0 0Q1 2 3 0Q5 -2 6 0Q9 2 9 3", "M0 0Q1 2 3 0T6 0Q9 2 9 3"},
+		{"M0 0A5 5 0 0 1 10 0A0 5 0 0 1 20 0A5 5 0 0 1 20 0", "M0 0A5 5 0 0 1 10 0H20"},
+		{"M1 1L1.00001 1.00001L1 5", "M1 1V5"},
+	}
+	for i, test := range tests {
+		path, err := ParsePath(test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := path.Optimize(1e-3).String(); actual != test.expected {
+			t.Errorf("test %d: expected %s but got %s", i, test.expected, actual)
+		}
+	}
+}
+
+func TestPathOptimizeGeometry(t *testing.T) {
+	path, err := ParsePath("M10 10C20 20 30 20 40 10S60 0 70 10Q75 15 80 10T90 10" +
+		"L90 20L90 30L100 30L100.0001 30A10 10 0 0 1 120 30Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	optimized := path.Optimize(1e-3)
+	if len(optimized.Format(FormatOptions{})) >=
+		len(path.Normalize().Format(FormatOptions{})) {
+		t.Error("path did not get shorter:", optimized)
+	}
+
+	expected := NewPathMeasure(path)
+	actual := NewPathMeasure(optimized)
+	if math.Abs(expected.Length()-actual.Length()) > 1e-2 {
+		t.Fatal("expected length", expected.Length(), "but got", actual.Length())
+	}
+	for i := 0; i <= 100; i++ {
+		distance := expected.Length() * float64(i) / 100
+		p1 := expected.PointAtLength(distance).Point
+		p2 := actual.PointAtLength(distance).Point
+		if (Line{p1, p2}).Length() > 1e-2 {
+			t.Error("at distance", distance, "expected", p1, "but got", p2)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package svg
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+)
+
+// A ParseErrorReason identifies the kind of problem described by a ParseError.
+type ParseErrorReason int
+
+const (
+	// ReasonUnexpectedCharacter means a character cannot appear where it was
+	// found, like an unknown command letter.
+	ReasonUnexpectedCharacter ParseErrorReason = iota
+
+	// ReasonMissingMoveTo means the data does not begin with a moveto command.
+	ReasonMissingMoveTo
+
+	// ReasonInvalidNumber means a number was expected but is malformed.
+	ReasonInvalidNumber
+
+	// ReasonInvalidFlag means an arc flag is something other than 0 or 1.
+	ReasonInvalidFlag
+
+	// ReasonNotEnoughArguments means a command has no complete call.
+	ReasonNotEnoughArguments
+
+	// ReasonInvalidArgumentCount means a command's last call is incomplete.
+	ReasonInvalidArgumentCount
+
+	// ReasonUnexpectedComma means a comma follows a command's last argument.
+	ReasonUnexpectedComma
+
+	// ReasonUnexpectedArguments means arguments were given to a closepath
+	// command.
+	ReasonUnexpectedArguments
+
+	// ReasonUnknownCommand means a PathCmd has a name which is not a command.
+	ReasonUnknownCommand
+
+	// ReasonUnknownTransform means a transform list names a function which
+	// does not exist.
+	ReasonUnknownTransform
+)
+
+var reasonDescriptions = []string{
+	"unexpected character",
+	"path must begin with a moveto command",
+	"invalid number",
+	"arc flag must be 0 or 1",
+	"not enough arguments",
+	"invalid number of arguments",
+	"unexpected comma",
+	"command takes no arguments",
+	"unknown command",
+	"unknown transform function",
+}
+
+// String returns a human-readable description of the reason.
+func (r ParseErrorReason) String() string {
+	if r < 0 || int(r) >= len(reasonDescriptions) {
+		return "ParseErrorReason(" + strconv.Itoa(int(r)) + ")"
+	}
+	return reasonDescriptions[r]
+}
+
+// A ParseError describes a problem with path data or a transform list, and
+// where it was found.
+type ParseError struct {
+	// Offset is the byte offset of Text within the path data. It is -1 for
+	// errors from Path.Validate, since those paths have no source text.
+	Offset int
+
+	// Line and Column give the 1-based position of Offset. Columns count
+	// characters rather than bytes. Both are 0 when Offset is -1.
+	Line   int
+	Column int
+
+	// Command is the index of the offending command within the path, of the
+	// offending number within a number list, or of the offending function
+	// within a transform list.
+	Command int
+
+	// Text is the offending text. For argument errors, it is the command and
+	// the arguments read so far. For errors from Path.Validate, it is the
+	// command's name.
+	Text string
+
+	Reason ParseErrorReason
+}
+
+func newParseError(data string, offset, command int, text string,
+	reason ParseErrorReason) *ParseError {
+	line, column := 1, 1
+	for _, r := range data[:offset] {
+		if r == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+	return &ParseError{offset, line, column, command, text, reason}
+}
+
+// Error returns a message containing the position, reason and offending text.
+func (p *ParseError) Error() string {
+	var position string
+	if p.Offset < 0 {
+		position = fmt.Sprintf("command %d", p.Command)
+	} else {
+		position = fmt.Sprintf("line %d, column %d", p.Line, p.Column)
+	}
+	text := p.Text
+	if utf8.RuneCountInString(text) > 20 {
+		text = string([]rune(text)[:20]) + "..."
+	}
+	return fmt.Sprintf("%s: %s: %q", position, p.Reason, text)
+}
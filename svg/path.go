@@ -2,16 +2,18 @@ package svg
 
 import (
 	"bytes"
-	"errors"
 	"strconv"
 	"strings"
-	"unicode"
 )
 
 type PathSegment interface {
 	Bounds() Rect
 	Length() float64
+	LengthWithTolerance(tolerance float64) float64
 	Evaluate(fraction float64) Point
+	Derivative(fraction float64) Point
+	SecondDerivative(fraction float64) Point
+	Flatten(tolerance float64) []Point
 	From() Point
 	To() Point
 }
@@ -41,47 +43,6 @@ func (c PathCmd) Equals(x PathCmd) bool {
 
 type Path []PathCmd
 
-func ParsePath(s string) (Path, error) {
-	path := Path{}
-
-	args := []float64{}
-	name := ""
-	argStr := ""
-
-	// NOTE: we add a "z" command to the path so that the command before it gets
-	// pushed to the resulting path.
-	for _, r := range s + "z" {
-		isArg := unicode.IsDigit(r) || r == '.'
-		if !isArg {
-			if argStr != "" {
-				if num, err := strconv.ParseFloat(argStr, 0); err != nil {
-					return nil, err
-				} else {
-					args = append(args, num)
-				}
-				argStr = ""
-			}
-		}
-		if unicode.IsLetter(r) {
-			if name != "" {
-				path = append(path, PathCmd{name, args})
-				args = []float64{}
-			}
-			name = string(r)
-		} else if isArg || r == '-' {
-			if name == "" {
-				return nil, errors.New("argument before first command name")
-			}
-			argStr += string(r)
-		}
-	}
-	if err := path.Validate(); err != nil {
-		return nil, err
-	} else {
-		return path, nil
-	}
-}
-
 // Absolute generates a path which only uses absolute commands. A path must be
 // validated before it can be made absolute.
 func (p Path) Absolute() Path {
@@ -259,10 +220,119 @@ func (p Path) Normalize() Path {
 	return res
 }
 
-// Segments turns a path's commands into a list of segments.
+// Reverse generates a path which traces the same shape backwards. Both the
+// order of the subpaths and the direction of each one are reversed. Closed
+// subpaths stay closed and begin at the same point as before. The result is a
+// normalized path. A path must be validated before it can be reversed.
+func (p Path) Reverse() Path {
+	normalized := p.Normalize()
+	res := make(Path, 0, len(normalized)+1)
+
+	// Subpaths are collected as their commands along with the point at which
+	// each command starts.
+	var cmds []PathCmd
+	var froms []Point
+	currentPoint := Point{0, 0}
+	subpathStart := Point{0, 0}
+	var reversedSubpaths []Path
+	flush := func(closed bool) {
+		if len(cmds) == 0 && !closed {
+			return
+		}
+		reversedSubpaths = append(reversedSubpaths,
+			reverseSubpath(cmds, froms, subpathStart, currentPoint, closed))
+		cmds, froms = nil, nil
+	}
+
+	for i, cmd := range normalized {
+		switch cmd.Name {
+		case "M":
+			flush(false)
+			if i+1 == len(normalized) || normalized[i+1].Name == "M" {
+				// Keep lone movetos, since they can still affect markers.
+				reversedSubpaths = append(reversedSubpaths, Path{cmd.Clone()})
+			}
+			subpathStart = Point{cmd.Args[0], cmd.Args[1]}
+		case "Z":
+			flush(true)
+			currentPoint = subpathStart
+			continue
+		default:
+			cmds = append(cmds, cmd)
+			froms = append(froms, currentPoint)
+		}
+		argCount := len(cmd.Args)
+		currentPoint = Point{cmd.Args[argCount-2], cmd.Args[argCount-1]}
+	}
+	flush(false)
+
+	for i := len(reversedSubpaths) - 1; i >= 0; i-- {
+		res = append(res, reversedSubpaths[i]...)
+	}
+	return res
+}
+
+func reverseSubpath(cmds []PathCmd, froms []Point, start, end Point, closed bool) Path {
+	var res Path
+	if closed {
+		res = append(res, PathCmd{"M", []float64{start.X, start.Y}})
+		if end != start {
+			res = append(res, PathCmd{"L", []float64{end.X, end.Y}})
+		}
+	} else {
+		res = append(res, PathCmd{"M", []float64{end.X, end.Y}})
+	}
+	for i := len(cmds) - 1; i >= 0; i-- {
+		cmd := cmds[i]
+		from := froms[i]
+		reversed := PathCmd{cmd.Name, []float64{}}
+		switch cmd.Name {
+		case "C":
+			reversed.Args = append(reversed.Args, cmd.Args[2], cmd.Args[3], cmd.Args[0], cmd.Args[1])
+		case "Q":
+			reversed.Args = append(reversed.Args, cmd.Args[0], cmd.Args[1])
+		case "A":
+			reversed.Args = append(reversed.Args, cmd.Args[:5]...)
+			reversed.Args[4] = 1 - reversed.Args[4]
+		}
+		reversed.Args = append(reversed.Args, from.X, from.Y)
+		res = append(res, reversed)
+	}
+	if closed {
+		// The closepath draws the final line, if there is one.
+		if last := res[len(res)-1]; last.Name == "L" && len(res) > 1 {
+			res = res[:len(res)-1]
+		}
+		res = append(res, PathCmd{"Z", []float64{}})
+	}
+	return res
+}
+
+// Segments turns a path's commands into a list of segments. The segments of
+// all subpaths are joined together; use Subpaths to tell them apart.
 func (p Path) Segments() []PathSegment {
+	res := []PathSegment{}
+	for _, subpath := range p.Subpaths() {
+		res = append(res, subpath.Segments...)
+	}
+	return res
+}
+
+// Subpaths splits a path into its subpaths and turns each one into a list of
+// segments. A closed subpath ends with the line drawn by its closepath
+// command, even if that line has no length. Subpaths without any segments,
+// like those made by consecutive moveto commands, are omitted.
+func (p Path) Subpaths() []Subpath {
 	normalized := p.Normalize()
-	res := make([]PathSegment, 0, len(normalized))
+	res := []Subpath{}
+
+	var current Subpath
+	finishSubpath := func() {
+		if len(current.Segments) > 0 {
+			res = append(res, current)
+		}
+		current = Subpath{}
+	}
 
 	currentPoint := Point{0, 0}
 	subpathStart := Point{0, 0}
@@ -270,35 +340,39 @@ func (p Path) Segments() []PathSegment {
 		argCount := len(cmd.Args)
 		switch cmd.Name {
 		case "M":
+			finishSubpath()
 			subpathStart = Point{cmd.Args[0], cmd.Args[1]}
 		case "L":
 			newPoint := Point{cmd.Args[0], cmd.Args[1]}
-			res = append(res, Line{currentPoint, newPoint})
+			current.Segments = append(current.Segments, Line{currentPoint, newPoint})
 		case "Z":
-			res = append(res, Line{currentPoint, subpathStart})
+			current.Segments = append(current.Segments, Line{currentPoint, subpathStart})
+			current.Closed = true
+			finishSubpath()
 			currentPoint = subpathStart
 		case "C":
-			res = append(res, &CubicBezier{currentPoint,
+			current.Segments = append(current.Segments, &CubicBezier{currentPoint,
 				Point{cmd.Args[0], cmd.Args[1]},
 				Point{cmd.Args[2], cmd.Args[3]},
 				Point{cmd.Args[4], cmd.Args[5]}})
 		case "Q":
-			res = append(res, &QuadraticBezier{currentPoint,
+			current.Segments = append(current.Segments, &QuadraticBezier{currentPoint,
 				Point{cmd.Args[0], cmd.Args[1]},
 				Point{cmd.Args[2], cmd.Args[3]}})
 		case "A":
 			arc := &Arc{currentPoint, Point{cmd.Args[5], cmd.Args[6]}, cmd.Args[0], cmd.Args[1],
 				cmd.Args[2], cmd.Args[3] != 0, cmd.Args[4] != 0}
 			if params, line := arc.Params(); params != nil {
-				res = append(res, params)
+				current.Segments = append(current.Segments, params)
 			} else {
-				res = append(res, *line)
+				current.Segments = append(current.Segments, *line)
 			}
 		}
 		if len(cmd.Args) >= 2 {
 			currentPoint = Point{cmd.Args[argCount-2], cmd.Args[argCount-1]}
 		}
 	}
+	finishSubpath()
 
 	return res
 }
@@ -314,14 +388,12 @@ func (p Path) SplitMulticalls() Path {
 	}
 
 	res := make(Path, 0, len(p))
-	argCounts := map[string]int{"m": 2, "z": 0, "l": 2, "h": 1, "v": 1, "c": 6,
-		"s": 4, "q": 4, "t": 2, "a": 7}
 	for _, cmd := range p {
 		if cmd.Name == "z" || cmd.Name == "Z" {
 			res = append(res, cmd.Clone())
 			continue
 		}
-		argCount := argCounts[strings.ToLower(cmd.Name)]
+		argCount := commandArgCounts[strings.ToLower(cmd.Name)]
 		for i := 0; i < len(cmd.Args); i += argCount {
 			subArgs := cmd.Args[i : i+argCount]
 			argCopy := make([]float64, argCount)
@@ -356,24 +428,26 @@ func (p Path) String() string {
 }
 
 // Validate makes sure that the path has valid commands and arguments. If not,
-// it returns an error describing the problem.
+// it returns a *ParseError describing the problem.
 func (p Path) Validate() error {
-	argCounts := map[string]int{"m": 2, "z": 0, "l": 2, "h": 1, "v": 1, "c": 6,
-		"s": 4, "q": 4, "t": 2, "a": 7}
-	for _, cmd := range p {
+	for i, cmd := range p {
 		lowerName := strings.ToLower(cmd.Name)
-		count, ok := argCounts[lowerName]
+		count, ok := commandArgCounts[lowerName]
 		if !ok {
-			return errors.New("unknown command: " + cmd.Name)
+			return validationError(i, cmd, ReasonUnknownCommand)
 		} else if lowerName == "z" {
 			if len(cmd.Args) != 0 {
-				return errors.New(cmd.Name + " command takes no arguments")
+				return validationError(i, cmd, ReasonUnexpectedArguments)
 			}
 		} else if len(cmd.Args) < count {
-			return errors.New("not enough arguments to " + cmd.Name)
+			return validationError(i, cmd, ReasonNotEnoughArguments)
 		} else if len(cmd.Args)%count != 0 {
-			return errors.New("invalid number of arguments to " + cmd.Name)
+			return validationError(i, cmd, ReasonInvalidArgumentCount)
 		}
 	}
 	return nil
 }
+
+func validationError(index int, cmd PathCmd, reason ParseErrorReason) *ParseError {
+	return &ParseError{Offset: -1, Command: index, Text: cmd.Name, Reason: reason}
+}
@@ -0,0 +1,277 @@
+package svg
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// commandArgCounts maps each lowercase command name to the number of
+// arguments it takes per call.
+var commandArgCounts = map[string]int{"m": 2, "z": 0, "l": 2, "h": 1, "v": 1, "c": 6,
+	"s": 4, "q": 4, "t": 2, "a": 7}
+
+// ParsePath parses path data as it would appear in the "d" attribute of a
+// <path> element. It follows the path data grammar from SVG 2, so numbers may
+// use exponents ("1e-3"), may be packed together ("0.5.5" is two numbers) and
+// arc flags need not be separated from the arguments around them.
+//
+// If the data is invalid, the returned error is a *ParseError.
+func ParsePath(s string) (Path, error) {
+	path, errs := ParsePathLenient(s)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return path, nil
+}
+
+// ParsePathLenient parses path data the way a browser renders it: everything
+// up to the first error is kept, including any complete calls of the command
+// which contains the error. The remaining data is still scanned so that every
+// error in it is reported, but none of it is included in the resulting path.
+func ParsePathLenient(s string) (Path, []*ParseError) {
+	scanner := &pathScanner{data: s}
+	path := Path{}
+	var errs []*ParseError
+
+	scanner.skipSpace()
+	for !scanner.done() {
+		start := scanner.pos
+		cmd, err := scanner.pathCommand()
+		if err != nil {
+			if len(errs) == 0 {
+				count := commandArgCounts[strings.ToLower(cmd.Name)]
+				if count > 0 && len(cmd.Args) >= count {
+					cmd.Args = cmd.Args[:len(cmd.Args)-len(cmd.Args)%count]
+					path = append(path, cmd)
+				}
+			}
+			errs = append(errs, err)
+			if scanner.pos == start {
+				_, size := utf8.DecodeRuneInString(scanner.data[start:])
+				scanner.pos += size
+			}
+			scanner.skipToCommand()
+		} else if len(errs) == 0 {
+			path = append(path, cmd)
+		}
+		scanner.command++
+	}
+
+	return path, errs
+}
+
+// pathScanner reads commands and numbers from path data according to the
+// grammar in https://www.w3.org/TR/SVG2/paths.html#PathDataBNF.
+type pathScanner struct {
+	data string
+	pos  int
+
+	// command is the index of the command being read, for error reporting.
+	command int
+}
+
+func (s *pathScanner) done() bool {
+	return s.pos >= len(s.data)
+}
+
+func (s *pathScanner) peek() byte {
+	if s.done() {
+		return 0
+	}
+	return s.data[s.pos]
+}
+
+func (s *pathScanner) skipSpace() {
+	for !s.done() && isPathSpace(s.peek()) {
+		s.pos++
+	}
+}
+
+// skipCommaSpace skips whitespace and at most one comma. It returns the
+// offset of the comma, or -1 if there was none.
+func (s *pathScanner) skipCommaSpace() int {
+	s.skipSpace()
+	if s.peek() != ',' {
+		return -1
+	}
+	comma := s.pos
+	s.pos++
+	s.skipSpace()
+	return comma
+}
+
+// skipToCommand moves to the next command letter, if there is one.
+func (s *pathScanner) skipToCommand() {
+	for ; !s.done(); s.pos++ {
+		if _, ok := commandArgCounts[strings.ToLower(s.data[s.pos:s.pos+1])]; ok {
+			return
+		}
+	}
+}
+
+// errorAt creates a ParseError for the text between start and end. If the
+// range is empty, the error refers to the next character, if there is one.
+func (s *pathScanner) errorAt(start, end int, reason ParseErrorReason) *ParseError {
+	if end <= start && start < len(s.data) {
+		_, size := utf8.DecodeRuneInString(s.data[start:])
+		end = start + size
+	}
+	text := strings.TrimRight(s.data[start:end], " \t\n\r\f")
+	return newParseError(s.data, start, s.command, text, reason)
+}
+
+// pathCommand reads a command letter and all of its arguments.
+func (s *pathScanner) pathCommand() (PathCmd, *ParseError) {
+	start := s.pos
+	name := s.data[s.pos : s.pos+1]
+	lowerName := strings.ToLower(name)
+	count, ok := commandArgCounts[lowerName]
+	if !ok {
+		if s.startsNumber() {
+			if s.command == 0 {
+				return PathCmd{}, s.errorAt(start, start, ReasonMissingMoveTo)
+			}
+			return PathCmd{}, s.errorAt(start, start, ReasonUnexpectedArguments)
+		}
+		return PathCmd{}, s.errorAt(start, start, ReasonUnexpectedCharacter)
+	} else if s.command == 0 && lowerName != "m" {
+		return PathCmd{}, s.errorAt(start, start, ReasonMissingMoveTo)
+	}
+	s.pos++
+	s.skipSpace()
+
+	cmd := PathCmd{name, []float64{}}
+	if count == 0 {
+		return cmd, nil
+	}
+	isArc := lowerName == "a"
+	for {
+		for i := 0; i < count; i++ {
+			if i > 0 {
+				s.skipCommaSpace()
+			}
+			var num float64
+			var err *ParseError
+			if isArc && (i == 3 || i == 4) {
+				num, err = s.flag()
+			} else {
+				num, err = s.number()
+			}
+			if err != nil {
+				if s.startsNumber() {
+					return cmd, err
+				} else if len(cmd.Args) < count {
+					return cmd, s.errorAt(start, s.pos, ReasonNotEnoughArguments)
+				}
+				return cmd, s.errorAt(start, s.pos, ReasonInvalidArgumentCount)
+			}
+			cmd.Args = append(cmd.Args, num)
+		}
+		comma := s.skipCommaSpace()
+		if !s.startsNumber() {
+			if comma >= 0 {
+				return cmd, s.errorAt(comma, comma+1, ReasonUnexpectedComma)
+			}
+			return cmd, nil
+		}
+	}
+}
+
+// startsNumber reports whether a number could begin at the current position.
+func (s *pathScanner) startsNumber() bool {
+	c := s.peek()
+	return isDigit(c) || c == '.' || c == '-' || c == '+'
+}
+
+// number reads a number, which may have a sign, a fraction and an exponent.
+func (s *pathScanner) number() (float64, *ParseError) {
+	start := s.pos
+	if c := s.peek(); c == '-' || c == '+' {
+		s.pos++
+	}
+	digits := s.skipDigits()
+	if s.peek() == '.' {
+		s.pos++
+		digits += s.skipDigits()
+	}
+	if digits == 0 {
+		end := s.pos
+		s.pos = start
+		return 0, s.errorAt(start, end, ReasonInvalidNumber)
+	}
+	if c := s.peek(); c == 'e' || c == 'E' {
+		// The exponent is only part of the number if it has digits;
+		// otherwise, the "e" is left for the caller to reject.
+		mark := s.pos
+		s.pos++
+		if c := s.peek(); c == '-' || c == '+' {
+			s.pos++
+		}
+		if s.skipDigits() == 0 {
+			s.pos = mark
+		}
+	}
+	num, err := strconv.ParseFloat(s.data[start:s.pos], 64)
+	if err != nil {
+		end := s.pos
+		s.pos = start
+		return 0, s.errorAt(start, end, ReasonInvalidNumber)
+	}
+	return num, nil
+}
+
+// flag reads a single-character arc flag, which must be "0" or "1".
+func (s *pathScanner) flag() (float64, *ParseError) {
+	switch s.peek() {
+	case '0':
+		s.pos++
+		return 0, nil
+	case '1':
+		s.pos++
+		return 1, nil
+	}
+	return 0, s.errorAt(s.pos, s.pos, ReasonInvalidFlag)
+}
+
+func (s *pathScanner) skipDigits() int {
+	count := 0
+	for isDigit(s.peek()) {
+		s.pos++
+		count++
+	}
+	return count
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isPathSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// ParseNumberList parses a list of numbers separated by whitespace and/or
+// commas, like the "points" attribute of a <polyline> element. Numbers follow
+// the same grammar as they do in path data.
+//
+// If the list is invalid, the returned error is a *ParseError.
+func ParseNumberList(s string) ([]float64, error) {
+	scanner := &pathScanner{data: s}
+	res := []float64{}
+
+	scanner.skipSpace()
+	for !scanner.done() {
+		num, err := scanner.number()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, num)
+		scanner.command++
+		if comma := scanner.skipCommaSpace(); comma >= 0 && scanner.done() {
+			return nil, scanner.errorAt(comma, comma+1, ReasonUnexpectedComma)
+		}
+	}
+
+	return res, nil
+}
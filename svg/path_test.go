@@ -1,6 +1,9 @@
 package svg
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestParsePath(t *testing.T) {
 	path, err := ParsePath(`M600,350 l 50,-25 a25,25 -30 0,1 50,-25 l50-25
@@ -43,6 +46,8 @@ func TestParsePath(t *testing.T) {
 		"m Z", "m", "z 1", "m 1", "m 1 2 3", "l", "l 1", "l 1 2 3",
 		"p 1 2 3", "x y z", "b 1 2",
 		"1", "1 2", "1 2 3", ",1",
+		"L 1 2", "M 1 2,", "M 1,,2", "M 1e", "M 1e+ 2", "M .", "M 1 2 a1 1 0 2 0 3 3",
+		"M 1 2 a1 1 0 0 -1 3 3", "M 1 2 Z 3 4",
 	}
 	for _, pathStr := range errorPaths {
 		if _, err := ParsePath(pathStr); err == nil {
@@ -51,6 +56,31 @@ func TestParsePath(t *testing.T) {
 	}
 }
 
+func TestParsePathNumbers(t *testing.T) {
+	path, err := ParsePath("M1e-3.5.5-1E2\tl+.5-0.5e1,2e+1 3\na10 10 0 0110 20" +
+		"A1,2,3,1,0,4,5a1 2 3 1,1-4-5z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Path{
+		{"M", []float64{1e-3, .5, .5, -100}},
+		{"l", []float64{.5, -5, 20, 3}},
+		{"a", []float64{10, 10, 0, 0, 1, 10, 20}},
+		{"A", []float64{1, 2, 3, 1, 0, 4, 5}},
+		{"a", []float64{1, 2, 3, 1, 1, -4, -5}},
+		{"z", []float64{}},
+	}
+	if len(expected) != len(path) {
+		t.Fatal("invalid length:", len(path))
+	}
+	for i, expect := range expected {
+		actual := path[i]
+		if !expect.Equals(actual) {
+			t.Error("path command", i, "should be", expect, "but is", actual)
+		}
+	}
+}
+
 func TestAbsolutePath(t *testing.T) {
 	path, err := ParsePath(`m 10,10,10-10 l 20,20 h 10-20 v 30
 		c 10,10 20-20 -20,30 s 10 10 20-20 q 10 10 20 0 t 20 0
@@ -120,3 +150,143 @@ func TestNormalize(t *testing.T) {
 		}
 	}
 }
+
+func TestParsePathErrors(t *testing.T) {
+	cases := []struct {
+		path string
+		err  ParseError
+	}{
+		{"M 1 2\n  L 3 4 C 1 2 3", ParseError{14, 2, 9, 2, "C 1 2 3", ReasonNotEnoughArguments}},
+		{"M 1 2 L 3 4 5", ParseError{6, 1, 7, 1, "L 3 4 5", ReasonInvalidArgumentCount}},
+		{"L 1 2", ParseError{0, 1, 1, 0, "L", ReasonMissingMoveTo}},
+		{"2 3", ParseError{0, 1, 1, 0, "2", ReasonMissingMoveTo}},
+		{"M1 2z3", ParseError{5, 1, 6, 2, "3", ReasonUnexpectedArguments}},
+		{"M1 2 x", ParseError{5, 1, 6, 1, "x", ReasonUnexpectedCharacter}},
+		{"M1 2 L1 1e", ParseError{9, 1, 10, 2, "e", ReasonUnexpectedCharacter}},
+		{"M1 2 L1 -", ParseError{8, 1, 9, 1, "-", ReasonInvalidNumber}},
+		{"M1 2 a1 1 0 2 0 3 3", ParseError{12, 1, 13, 1, "2", ReasonInvalidFlag}},
+		{"M1 2, L3 4", ParseError{4, 1, 5, 0, ",", ReasonUnexpectedComma}},
+	}
+	for _, c := range cases {
+		_, err := ParsePath(c.path)
+		if parseErr, ok := err.(*ParseError); !ok {
+			t.Errorf("expected ParseError for %q but got %v", c.path, err)
+		} else if *parseErr != c.err {
+			t.Errorf("expected %#v for %q but got %#v", c.err, c.path, *parseErr)
+		}
+	}
+
+	err := Path{{"M", []float64{1, 2}}, {"L", []float64{1}}}.Validate()
+	expected := ParseError{-1, 0, 0, 1, "L", ReasonNotEnoughArguments}
+	if parseErr, ok := err.(*ParseError); !ok || *parseErr != expected {
+		t.Errorf("expected %#v but got %v", expected, err)
+	}
+}
+
+func TestParsePathLenient(t *testing.T) {
+	cases := []struct {
+		path     string
+		expected string
+		errors   []ParseErrorReason
+	}{
+		{"M1 2L3 4", "M1 2L3 4", nil},
+		{"M1 2L3 4 5 6 7C1 2 3", "M1 2L3 4 5 6", []ParseErrorReason{ReasonInvalidArgumentCount,
+			ReasonNotEnoughArguments}},
+		{"M1 2L3 4x5 6L7 8", "M1 2L3 4", []ParseErrorReason{ReasonUnexpectedCharacter}},
+		{"M1 2 a1 1 0 2 0 3 3 L 1 2 Z 3", "M1 2", []ParseErrorReason{ReasonInvalidFlag,
+			ReasonUnexpectedArguments}},
+		{"L1 2 M1 2", "", []ParseErrorReason{ReasonMissingMoveTo}},
+	}
+	for _, c := range cases {
+		path, errs := ParsePathLenient(c.path)
+		if path.String() != c.expected {
+			t.Errorf("expected %q for %q but got %q", c.expected, c.path, path.String())
+		}
+		if len(errs) != len(c.errors) {
+			t.Errorf("expected %d errors for %q but got %v", len(c.errors), c.path, errs)
+			continue
+		}
+		for i, err := range errs {
+			if err.Reason != c.errors[i] {
+				t.Errorf("error %d for %q should be %s but is %s", i, c.path, c.errors[i],
+					err.Reason)
+			}
+		}
+	}
+}
+
+func TestParsePathUnicode(t *testing.T) {
+	path, errs := ParsePathLenient("M1 2é L3 4 €€")
+	if path.String() != "M1 2" {
+		t.Error("unexpected path:", path)
+	}
+	expected := []string{"é", "€"}
+	if len(errs) != len(expected) {
+		t.Fatal("expected", len(expected), "errors but got", errs)
+	}
+	for i, err := range errs {
+		if err.Reason != ReasonUnexpectedCharacter || err.Text != expected[i] {
+			t.Errorf("error %d should be an unexpected %q but is %s %q", i, expected[i],
+				err.Reason, err.Text)
+		}
+	}
+}
+
+func TestParseNumberList(t *testing.T) {
+	nums, err := ParseNumberList(" 1,2 3-4.5.5e1\n,6 ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []float64{1, 2, 3, -4.5, 5, 6}
+	if len(nums) != len(expected) {
+		t.Fatal("expected", expected, "but got", nums)
+	}
+	for i, x := range expected {
+		if nums[i] != x {
+			t.Error("number", i, "should be", x, "but it is", nums[i])
+		}
+	}
+	for _, list := range []string{"1,", "1,,2", "1 x"} {
+		if _, err := ParseNumberList(list); err == nil {
+			t.Error("expected list to trigger error:", list)
+		}
+	}
+}
+
+func TestReverse(t *testing.T) {
+	cases := map[string]string{
+		"M10,10 L20,10 L20,20":               "M20 20L20 10L10 10",
+		"M10,10 h10 v10 z":                   "M10 10L20 20L20 10Z",
+		"M10,10 h10 v10 L10,10 z":            "M10 10L20 20L20 10Z",
+		"M0,0 C1,2 3,4 5,6 Q7,8 9,10":        "M9 10Q7 8 5 6C3 4 1 2 0 0",
+		"M0,0 A5,10 30 1 0 10,0 z M20,20 h5": "M25 20L20 20M0 0L10 0A5 10 30 1 1 0 0Z",
+		"M0,0 h10 z l5,5 M1,1":               "M1 1M5 5L0 0M0 0L10 0Z",
+	}
+	for input, expected := range cases {
+		path, err := ParsePath(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := path.Reverse().String(); actual != expected {
+			t.Errorf("reversing %q should give %q but gave %q", input, expected, actual)
+		}
+	}
+
+	path, err := ParsePath(`M10,50 C40,10 70,90 100,50 Q120,0 130,50
+		A20,10 30 0 1 150,60 L200,60 Z M 0,0 h 10`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forward := NewPathMeasure(path)
+	backward := NewPathMeasure(path.Reverse())
+	if math.Abs(forward.Length()-backward.Length()) > 1e-6 {
+		t.Fatal("reversed path has length", backward.Length(), "instead of", forward.Length())
+	}
+	for distance := 0.0; distance < forward.Length(); distance += forward.Length() / 17 {
+		p1 := forward.PointAtLength(distance).Point
+		p2 := backward.PointAtLength(backward.Length() - distance).Point
+		if !p1.approxEqual(p2) {
+			t.Error("point at", distance, "should be", p1, "in reverse but is", p2)
+		}
+	}
+}
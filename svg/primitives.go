@@ -47,6 +47,11 @@ func (l Line) Length() float64 {
 	return math.Sqrt(math.Pow(l.End.X-l.Start.X, 2) + math.Pow(l.End.Y-l.Start.Y, 2))
 }
 
+// LengthWithTolerance returns the length of the line, which is always exact.
+func (l Line) LengthWithTolerance(tolerance float64) float64 {
+	return l.Length()
+}
+
 // Midpoint returns the midpoint of the line.
 func (l Line) Midpoint() Point {
 	return l.Evaluate(0.5)
@@ -66,3 +71,14 @@ func (l Line) From() Point {
 func (l Line) To() Point {
 	return l.End
 }
+
+// Derivative returns the derivative of the line, which is the same for
+// every t.
+func (l Line) Derivative(t float64) Point {
+	return Point{l.End.X - l.Start.X, l.End.Y - l.Start.Y}
+}
+
+// SecondDerivative returns the second derivative of the line, which is zero.
+func (l Line) SecondDerivative(t float64) Point {
+	return Point{}
+}
@@ -0,0 +1,149 @@
+package svg
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+)
+
+// A FillRule determines which points are inside of a path, like the SVG
+// fill-rule property.
+type FillRule int
+
+const (
+	// NonZero fills points around which the path winds a non-zero number of
+	// times.
+	NonZero FillRule = iota
+
+	// EvenOdd fills points which a ray from the point crosses the path an odd
+	// number of times to get away from.
+	EvenOdd
+)
+
+const (
+	// rasterSubsamples is the number of sub-scanlines sampled in each row of
+	// pixels.
+	rasterSubsamples = 16
+
+	// rasterTolerance is the maximum error, in pixels, of the polylines which
+	// approximate a path's curves.
+	rasterTolerance = 0.05
+)
+
+// FillPath draws the inside of a path onto an image with anti-aliasing. The
+// path's coordinates are in pixels, with the top-left corner of the image's
+// bounds at the origin of the image's coordinate system. Open subpaths are
+// filled as if they were closed. The color is composited over the image's
+// contents. A path must be validated before it can be filled.
+//
+// Each pixel's coverage is found by intersecting the path with several
+// evenly spaced horizontal lines, and measuring the parts of those lines which
+// are inside of the path exactly.
+func FillPath(img *image.RGBA, p Path, c color.Color, rule FillRule) {
+	bounds := img.Bounds()
+	edges := rasterEdges(p)
+	if len(edges) == 0 || bounds.Empty() {
+		return
+	}
+
+	coverage := make([]float64, bounds.Dx())
+	var crossings []rasterCrossing
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for i := range coverage {
+			coverage[i] = 0
+		}
+		for sample := 0; sample < rasterSubsamples; sample++ {
+			scanY := float64(y) + (float64(sample)+0.5)/rasterSubsamples
+			crossings = crossings[:0]
+			for _, edge := range edges {
+				if x, ok := edge.crossing(scanY); ok {
+					crossings = append(crossings, rasterCrossing{x, edge.winding})
+				}
+			}
+			sort.Slice(crossings, func(i, j int) bool {
+				return crossings[i].x < crossings[j].x
+			})
+			var winding int
+			for i := 0; i+1 < len(crossings); i++ {
+				winding += crossings[i].winding
+				if (rule == NonZero && winding != 0) || (rule == EvenOdd && winding%2 != 0) {
+					addCoverage(coverage, float64(bounds.Min.X), crossings[i].x,
+						crossings[i+1].x)
+				}
+			}
+		}
+		for i, amount := range coverage {
+			if amount > 0 {
+				blendPixel(img, bounds.Min.X+i, y, c, math.Min(1, amount))
+			}
+		}
+	}
+}
+
+// A rasterEdge is a non-horizontal line from a flattened path.
+type rasterEdge struct {
+	start, end Point
+
+	// winding is 1 if the edge goes down and -1 if it goes up.
+	winding int
+}
+
+// crossing finds where the edge crosses a horizontal line. Edges include
+// their top end but not their bottom end, so that the crossing at a vertex is
+// only counted once.
+func (r *rasterEdge) crossing(y float64) (float64, bool) {
+	if y < r.start.Y || y >= r.end.Y {
+		return 0, false
+	}
+	t := (y - r.start.Y) / (r.end.Y - r.start.Y)
+	return r.start.X + t*(r.end.X-r.start.X), true
+}
+
+type rasterCrossing struct {
+	x       float64
+	winding int
+}
+
+// rasterEdges flattens a path into edges with their tops first.
+func rasterEdges(p Path) []rasterEdge {
+	var res []rasterEdge
+	for _, polyline := range p.Flatten(rasterTolerance) {
+		for i, start := range polyline {
+			end := polyline[(i+1)%len(polyline)]
+			if start.Y < end.Y {
+				res = append(res, rasterEdge{start, end, 1})
+			} else if start.Y > end.Y {
+				res = append(res, rasterEdge{end, start, -1})
+			}
+		}
+	}
+	return res
+}
+
+// addCoverage adds the part of each pixel in a row between x0 and x1 to the
+// pixel's coverage, for a single sub-scanline. The first pixel in the row is
+// at minX.
+func addCoverage(coverage []float64, minX, x0, x1 float64) {
+	x0 = math.Max(x0-minX, 0)
+	x1 = math.Min(x1-minX, float64(len(coverage)))
+	for x0 < x1 {
+		pixel := math.Floor(x0)
+		next := math.Min(pixel+1, x1)
+		coverage[int(pixel)] += (next - x0) / rasterSubsamples
+		x0 = next
+	}
+}
+
+// blendPixel composites a color with some coverage over a pixel.
+func blendPixel(img *image.RGBA, x, y int, c color.Color, coverage float64) {
+	r, g, b, a := c.RGBA()
+	srcAlpha := float64(a) * coverage
+	i := img.PixOffset(x, y)
+	pixel := img.Pix[i : i+4 : i+4]
+	for j, src := range []uint32{r, g, b, a} {
+		dst := float64(pixel[j]) * 0x101
+		value := float64(src)*coverage + dst*(1-srcAlpha/0xffff)
+		pixel[j] = uint8(math.Round(value / 0x101))
+	}
+}
@@ -0,0 +1,67 @@
+package svg
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestFillPathSquare(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 20, 20))
+	path, _ := ParsePath("M5 5H15.5V15H5Z")
+	FillPath(img, path, color.RGBA{255, 0, 0, 255}, NonZero)
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 20; x++ {
+			expected := color.RGBA{}
+			if y >= 5 && y < 15 && x >= 5 && x < 15 {
+				expected = color.RGBA{255, 0, 0, 255}
+			} else if y >= 5 && y < 15 && x == 15 {
+				expected = color.RGBA{128, 0, 0, 128}
+			}
+			if actual := img.RGBAAt(x, y); actual != expected {
+				t.Errorf("pixel (%d, %d) should be %v but is %v", x, y, expected, actual)
+			}
+		}
+	}
+}
+
+func TestFillPathRules(t *testing.T) {
+	// The inner square winds the same way as the outer one.
+	path, _ := ParsePath("M0 0H10V10H0ZM3 3H7V7H3Z")
+	for _, rule := range []FillRule{NonZero, EvenOdd} {
+		img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+		FillPath(img, path, color.Black, rule)
+		if img.RGBAAt(1, 1).A != 255 {
+			t.Error("rule", rule, "should fill the outer square")
+		}
+		if filled := img.RGBAAt(5, 5).A == 255; filled != (rule == NonZero) {
+			t.Error("rule", rule, "gave the wrong result for the hole")
+		}
+	}
+}
+
+func TestFillPathCircle(t *testing.T) {
+	img := image.NewRGBA(image.Rect(-20, -20, 20, 20))
+	path, _ := NewPathBuilder().Circle(0.3, -0.2, 15).Path()
+	FillPath(img, path, color.NRGBA{0, 0, 255, 128}, EvenOdd)
+	var area float64
+	for y := -20; y < 20; y++ {
+		for x := -20; x < 20; x++ {
+			area += float64(img.RGBAAt(x, y).A) / 128
+		}
+	}
+	if expected := math.Pi * 15 * 15; math.Abs(area-expected) > expected*0.005 {
+		t.Error("expected area", expected, "but got", area)
+	}
+}
+
+func TestFillPathBlend(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	img.SetRGBA(0, 0, color.RGBA{0, 0, 200, 255})
+	path, _ := ParsePath("M0 0H1V1H0Z")
+	FillPath(img, path, color.NRGBA{255, 0, 0, 128}, NonZero)
+	if actual, expected := img.RGBAAt(0, 0), (color.RGBA{128, 0, 100, 255}); actual != expected {
+		t.Error("expected", expected, "but got", actual)
+	}
+}
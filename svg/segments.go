@@ -0,0 +1,85 @@
+package svg
+
+// A Subpath is a connected run of segments from a path, which starts at a
+// moveto command.
+type Subpath struct {
+	Segments []PathSegment
+
+	// Closed is true if the subpath ends with a closepath command. In that
+	// case, the last segment is the line drawn by the closepath.
+	Closed bool
+}
+
+// PathFromSubpaths converts subpaths back into a normalized path, which is the
+// reverse of Path.Subpaths. Each subpath starts with a moveto command, and
+// closed subpaths end with a closepath command. The segments of each subpath
+// must be connected, and they must be of the types accepted by
+// PathFromSegments.
+func PathFromSubpaths(subpaths []Subpath) Path {
+	res := Path{}
+	for _, subpath := range subpaths {
+		if len(subpath.Segments) == 0 {
+			continue
+		}
+		start := subpath.Segments[0].From()
+		res = append(res, PathCmd{"M", []float64{start.X, start.Y}})
+		segments := subpath.Segments
+		if subpath.Closed {
+			// The closepath draws the final line, if there is one.
+			last := segments[len(segments)-1]
+			if _, ok := last.(Line); ok && last.To().approxEqual(start) {
+				segments = segments[:len(segments)-1]
+			}
+		}
+		for _, segment := range segments {
+			res = append(res, segmentCommand(segment))
+		}
+		if subpath.Closed {
+			res = append(res, PathCmd{"Z", []float64{}})
+		}
+	}
+	return res
+}
+
+// PathFromSegments converts segments back into a normalized path, which is
+// the reverse of Path.Segments. A moveto command starts a new subpath wherever
+// a segment does not begin where the previous one ended. Arcs are written as
+// "A" commands in endpoint form.
+//
+// Every segment must be a Line, *QuadraticBezier, *CubicBezier or *ArcParams.
+func PathFromSegments(segments []PathSegment) Path {
+	res := Path{}
+	for i, segment := range segments {
+		if i == 0 || !segments[i-1].To().approxEqual(segment.From()) {
+			res = append(res, PathCmd{"M", []float64{segment.From().X, segment.From().Y}})
+		}
+		res = append(res, segmentCommand(segment))
+	}
+	return res
+}
+
+// segmentCommand creates a command which draws a segment from its start.
+func segmentCommand(s PathSegment) PathCmd {
+	end := s.To()
+	switch s := s.(type) {
+	case Line:
+		return PathCmd{"L", []float64{end.X, end.Y}}
+	case *QuadraticBezier:
+		return PathCmd{"Q", []float64{s.Control.X, s.Control.Y, end.X, end.Y}}
+	case *CubicBezier:
+		return PathCmd{"C", []float64{s.Control1.X, s.Control1.Y, s.Control2.X, s.Control2.Y,
+			end.X, end.Y}}
+	case *ArcParams:
+		arc := s.Arc()
+		var largeArc, sweep float64
+		if arc.LargeArc {
+			largeArc = 1
+		}
+		if arc.Sweep {
+			sweep = 1
+		}
+		return PathCmd{"A", []float64{arc.XRadius, arc.YRadius, arc.Rotation, largeArc, sweep,
+			end.X, end.Y}}
+	}
+	panic("unsupported segment type")
+}
@@ -0,0 +1,81 @@
+package svg
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPathFromSegments(t *testing.T) {
+	path, err := ParsePath(`M10,50 C40,10 70,90 100,50 Q120,0 130,50 A20,10 30 0 1 150,60
+		a20,20 0 1 0 10,0 h20 M0,0 h5 v5 a5,1 0 0 1 -30,0 z`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	segments := path.Segments()
+	rebuilt := PathFromSegments(segments)
+	if len(rebuilt) != len(segments)+2 || rebuilt[0].Name != "M" || rebuilt[6].Name != "M" {
+		t.Fatal("unexpected structure:", rebuilt)
+	}
+	rebuiltSegments := rebuilt.Segments()
+	if len(rebuiltSegments) != len(segments) {
+		t.Fatal("expected", len(segments), "segments but got", len(rebuiltSegments))
+	}
+	for i, segment := range segments {
+		for _, frac := range []float64{0, 0.3, 0.5, 0.8, 1} {
+			expected := segment.Evaluate(frac)
+			if actual := rebuiltSegments[i].Evaluate(frac); !actual.approxEqual(expected) {
+				t.Error("segment", i, "should give", expected, "at", frac, "but gives", actual)
+			}
+		}
+	}
+
+	if path := PathFromSegments(nil); len(path) != 0 {
+		t.Error("expected empty path but got", path)
+	}
+}
+
+func TestArcParamsArc(t *testing.T) {
+	arcs := []Arc{
+		{Point{10, 10}, Point{30, 30}, 20, 20, 0, false, true},
+		{Point{10, 10}, Point{30, 30}, 20, 20, 0, true, true},
+		{Point{50, 10}, Point{60, 20}, 10, 20, 30, true, false},
+		{Point{60, 20}, Point{50, 10}, 10, 20, 30, false, true},
+	}
+	for i, arc := range arcs {
+		params, _ := arc.Params()
+		actual := params.Arc()
+		if !actual.Start.approxEqual(arc.Start) || !actual.End.approxEqual(arc.End) ||
+			actual.XRadius != arc.XRadius || actual.YRadius != arc.YRadius ||
+			actual.Rotation != arc.Rotation || actual.LargeArc != arc.LargeArc ||
+			actual.Sweep != arc.Sweep {
+			t.Error("arc", i, "should be", arc, "but got", *actual)
+		}
+	}
+}
+
+func TestSubpaths(t *testing.T) {
+	path, err := ParsePath("M0 0 L10 0 L10 10 Z M20 20 M30 30 Q40 40 50 30 z l-30 -25 h5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	subpaths := path.Subpaths()
+	expected := []Subpath{
+		{[]PathSegment{Line{Point{0, 0}, Point{10, 0}}, Line{Point{10, 0}, Point{10, 10}},
+			Line{Point{10, 10}, Point{0, 0}}}, true},
+		{[]PathSegment{&QuadraticBezier{Point{30, 30}, Point{40, 40}, Point{50, 30}},
+			Line{Point{50, 30}, Point{30, 30}}}, true},
+		{[]PathSegment{Line{Point{30, 30}, Point{0, 5}}, Line{Point{0, 5}, Point{5, 5}}}, false},
+	}
+	if !reflect.DeepEqual(subpaths, expected) {
+		t.Fatal("expected", expected, "but got", subpaths)
+	}
+
+	rebuilt := PathFromSubpaths(subpaths).String()
+	if expected := "M0 0L10 0L10 10ZM30 30Q40 40 50 30ZM30 30L0 5L5 5"; rebuilt != expected {
+		t.Error("expected", expected, "but got", rebuilt)
+	}
+
+	if subpaths := (Path{}).Subpaths(); len(subpaths) != 0 {
+		t.Error("expected no subpaths but got", subpaths)
+	}
+}
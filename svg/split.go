@@ -0,0 +1,136 @@
+package svg
+
+// Split divides the line at parameter t.
+func (l Line) Split(t float64) (Line, Line) {
+	mid := l.Evaluate(t)
+	return Line{l.Start, mid}, Line{mid, l.End}
+}
+
+// Subsegment returns the part of the line between parameters t0 and t1,
+// where t0 <= t1.
+func (l Line) Subsegment(t0, t1 float64) Line {
+	return Line{l.Evaluate(t0), l.Evaluate(t1)}
+}
+
+// Split divides the curve at parameter t using de Casteljau's algorithm.
+func (q *QuadraticBezier) Split(t float64) (*QuadraticBezier, *QuadraticBezier) {
+	p1 := Line{q.Start, q.Control}.Evaluate(t)
+	p2 := Line{q.Control, q.End}.Evaluate(t)
+	mid := Line{p1, p2}.Evaluate(t)
+	return &QuadraticBezier{q.Start, p1, mid}, &QuadraticBezier{mid, p2, q.End}
+}
+
+// Subsegment returns the part of the curve between parameters t0 and t1,
+// where t0 <= t1.
+func (q *QuadraticBezier) Subsegment(t0, t1 float64) *QuadraticBezier {
+	if t1 == 0 {
+		return &QuadraticBezier{q.Start, q.Start, q.Start}
+	}
+	left, _ := q.Split(t1)
+	_, res := left.Split(t0 / t1)
+	return res
+}
+
+// Split divides the curve at parameter t using de Casteljau's algorithm.
+func (c *CubicBezier) Split(t float64) (*CubicBezier, *CubicBezier) {
+	p1 := Line{c.Start, c.Control1}.Evaluate(t)
+	p2 := Line{c.Control1, c.Control2}.Evaluate(t)
+	p3 := Line{c.Control2, c.End}.Evaluate(t)
+	p12 := Line{p1, p2}.Evaluate(t)
+	p23 := Line{p2, p3}.Evaluate(t)
+	mid := Line{p12, p23}.Evaluate(t)
+	return &CubicBezier{c.Start, p1, p12, mid}, &CubicBezier{mid, p23, p3, c.End}
+}
+
+// Subsegment returns the part of the curve between parameters t0 and t1,
+// where t0 <= t1.
+func (c *CubicBezier) Subsegment(t0, t1 float64) *CubicBezier {
+	if t1 == 0 {
+		return &CubicBezier{c.Start, c.Start, c.Start, c.Start}
+	}
+	left, _ := c.Split(t1)
+	_, res := left.Split(t0 / t1)
+	return res
+}
+
+// Split divides the arc at parameter t by splitting its range of angles.
+func (a *ArcParams) Split(t float64) (*ArcParams, *ArcParams) {
+	return a.Subsegment(0, t), a.Subsegment(t, 1)
+}
+
+// Subsegment returns the part of the arc between parameters t0 and t1, where
+// t0 <= t1.
+func (a *ArcParams) Subsegment(t0, t1 float64) *ArcParams {
+	res := *a
+	delta := a.angleDelta()
+	res.StartAngle = clipDegreesTo360(a.StartAngle + t0*delta)
+	res.EndAngle = clipDegreesTo360(a.StartAngle + t1*delta)
+	return &res
+}
+
+// Trim returns the part of the path between two distances along it, which
+// are clamped to the length of the path. Moves between subpaths do not count
+// towards distances, but the result starts a new subpath wherever the
+// original path does. A closed subpath stays closed if all of it is between
+// the distances. The path must be valid.
+func (p Path) Trim(startDistance, endDistance float64) Path {
+	var res []Subpath
+	var position float64
+	for _, subpath := range p.Subpaths() {
+		measure := newSegmentMeasure(subpath.Segments)
+		length := measure.Length()
+		start, end := 0.0, length
+		if startDistance > position {
+			start = startDistance - position
+		}
+		if endDistance < position+length {
+			end = endDistance - position
+		}
+		position += length
+		if start >= end {
+			continue
+		}
+		if subpath.Closed && start == 0 && end == length {
+			res = append(res, subpath)
+		} else {
+			res = append(res, Subpath{Segments: measure.segmentsBetween(start, end)})
+		}
+	}
+	return PathFromSubpaths(res)
+}
+
+// segmentsBetween returns the parts of the segments between two distances
+// along the path, where 0 <= startDistance <= endDistance <= m.Length().
+func (m *PathMeasure) segmentsBetween(startDistance, endDistance float64) []PathSegment {
+	start := m.PointAtLength(startDistance)
+	end := m.PointAtLength(endDistance)
+	var segments []PathSegment
+	for i := start.Segment; i <= end.Segment; i++ {
+		t0, t1 := 0.0, 1.0
+		if i == start.Segment {
+			t0 = start.T
+		}
+		if i == end.Segment {
+			t1 = end.T
+		}
+		if t0 < t1 {
+			segments = append(segments, subsegment(m.segments[i], t0, t1))
+		}
+	}
+	return segments
+}
+
+// subsegment calls Subsegment on any segment type from this package.
+func subsegment(s PathSegment, t0, t1 float64) PathSegment {
+	switch s := s.(type) {
+	case Line:
+		return s.Subsegment(t0, t1)
+	case *QuadraticBezier:
+		return s.Subsegment(t0, t1)
+	case *CubicBezier:
+		return s.Subsegment(t0, t1)
+	case *ArcParams:
+		return s.Subsegment(t0, t1)
+	}
+	panic("unsupported segment type")
+}
@@ -0,0 +1,95 @@
+package svg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	arc, _ := (&Arc{Point{50, 10}, Point{60, 20}, 10, 20, 30, true, false}).Params()
+	segments := []PathSegment{
+		Line{Point{1, 2}, Point{5, -3}},
+		&QuadraticBezier{Point{10, 10}, Point{40, 40}, Point{20, 50}},
+		&CubicBezier{Point{96, 89}, Point{13, 46}, Point{14, 64}, Point{15, 91}},
+		arc,
+	}
+	for i, s := range segments {
+		for _, split := range []float64{0, 0.3, 0.5, 1} {
+			var left, right PathSegment
+			switch s := s.(type) {
+			case Line:
+				left, right = s.Split(split)
+			case *QuadraticBezier:
+				left, right = s.Split(split)
+			case *CubicBezier:
+				left, right = s.Split(split)
+			case *ArcParams:
+				left, right = s.Split(split)
+			}
+			for _, frac := range []float64{0, 0.2, 0.7, 1} {
+				if !left.Evaluate(frac).approxEqual(s.Evaluate(frac * split)) {
+					t.Error("segment", i, "has bad left half at", split)
+				}
+				if !right.Evaluate(frac).approxEqual(s.Evaluate(split + frac*(1-split))) {
+					t.Error("segment", i, "has bad right half at", split)
+				}
+			}
+		}
+
+		sub := subsegment(s, 0.25, 0.6)
+		for _, frac := range []float64{0, 0.2, 0.7, 1} {
+			if !sub.Evaluate(frac).approxEqual(s.Evaluate(0.25 + frac*0.35)) {
+				t.Error("segment", i, "has bad subsegment at", frac)
+			}
+		}
+	}
+}
+
+func TestTrim(t *testing.T) {
+	path, err := ParsePath("M0 0 h10 v10 M20 0 h10 z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trimmed := path.Trim(5, 25).String(); trimmed != "M5 0L10 0L10 10M20 0L25 0" {
+		t.Error("unexpected trimmed path:", trimmed)
+	}
+	if trimmed := path.Trim(-5, 15).String(); trimmed != "M0 0L10 0L10 5" {
+		t.Error("unexpected trimmed path:", trimmed)
+	}
+	if trimmed := path.Trim(10, 10); len(trimmed) != 0 {
+		t.Error("empty range should give empty path but got", trimmed)
+	}
+
+	path, err = ParsePath("M0 0H10V10H0ZM0 0H-10V-10Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, end := range []float64{100, NewPathMeasure(path).Length()} {
+		trimmed := path.Trim(0, end).String()
+		if trimmed != "M0 0L10 0L10 10L0 10ZM0 0L-10 0L-10-10Z" {
+			t.Error("unexpected trimmed path:", trimmed)
+		}
+	}
+	if trimmed := path.Trim(0, 40).String(); trimmed != "M0 0L10 0L10 10L0 10Z" {
+		t.Error("unexpected trimmed path:", trimmed)
+	}
+	if trimmed := path.Trim(5, 45).String(); trimmed != "M5 0L10 0L10 10L0 10L0 0M0 0L-5 0" {
+		t.Error("unexpected trimmed path:", trimmed)
+	}
+
+	path, err = ParsePath("M10,50 C40,10 70,90 100,50 Q120,0 130,50 A20,10 30 0 1 150,60")
+	if err != nil {
+		t.Fatal(err)
+	}
+	measure := NewPathMeasure(path)
+	for _, r := range [][2]float64{{0, 10}, {30, 100}, {55, 130}, {100, measure.Length()}} {
+		trimmed := NewPathMeasure(path.Trim(r[0], r[1]))
+		if math.Abs(trimmed.Length()-(r[1]-r[0])) > 1e-6 {
+			t.Error("trimming to", r, "gave length", trimmed.Length())
+		}
+		start := trimmed.PointAtLength(0).Point
+		if expected := measure.PointAtLength(r[0]).Point; !start.approxEqual(expected) {
+			t.Error("trimming to", r, "should start at", expected, "but starts at", start)
+		}
+	}
+}
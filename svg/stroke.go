@@ -0,0 +1,421 @@
+package svg
+
+import "math"
+
+// DefaultStrokeTolerance is the tolerance Stroke uses when StrokeOptions does
+// not specify one.
+const DefaultStrokeTolerance = 1e-2
+
+// DefaultMiterLimit is the miter limit Stroke uses when StrokeOptions does not
+// specify one, which is the default of the SVG stroke-miterlimit property.
+const DefaultMiterLimit = 4
+
+// A LineJoin determines the shape of the corners of a stroke, like the SVG
+// stroke-linejoin property.
+type LineJoin int
+
+const (
+	// MiterJoin extends the outer edges of a corner until they meet, or bevels
+	// the corner if they would meet further away than the miter limit allows.
+	MiterJoin LineJoin = iota
+
+	// RoundJoin rounds corners with a circular arc.
+	RoundJoin
+
+	// BevelJoin cuts corners off with a straight line.
+	BevelJoin
+
+	// ArcsJoin extends the outer edges of a corner with circular arcs which
+	// match their curvature until they meet. Like MiterJoin, it bevels the
+	// corner if they would meet beyond the miter limit. Where the arcs do not
+	// meet, the corner is joined like MiterJoin.
+	ArcsJoin
+)
+
+// A LineCap determines the shape of the ends of a stroke's open subpaths,
+// like the SVG stroke-linecap property.
+type LineCap int
+
+const (
+	// ButtCap ends strokes right at the ends of the subpaths.
+	ButtCap LineCap = iota
+
+	// RoundCap ends strokes with half circles.
+	RoundCap
+
+	// SquareCap ends strokes with half squares.
+	SquareCap
+)
+
+// StrokeOptions describes the stroke of a path.
+type StrokeOptions struct {
+	Width float64
+	Join  LineJoin
+	Cap   LineCap
+
+	// MiterLimit is the maximum ratio of the length of a miter to the width of
+	// the stroke. If it is less than 1, DefaultMiterLimit is used.
+	MiterLimit float64
+
+	// Tolerance is the maximum distance between the outline and the true edge
+	// of the stroke. If it is not positive, DefaultStrokeTolerance is used.
+	Tolerance float64
+}
+
+// Stroke computes the outline of a path's stroke, which is a path that draws
+// the stroke when it is filled with the NonZero fill rule. The outline of each
+// open subpath goes around both sides and the caps, and the outline of each
+// closed subpath is made of one closed subpath on each side. Subpaths with no
+// length get caps, facing along the x axis, like they do in SVG. The edges
+// beside the segments are made with their Offset methods, so curves stay
+// curves.
+//
+// The outline may overlap itself where the path turns sharply or the stroke
+// is wider than the path's curvature allows, which the fill rule takes care
+// of. A path must be validated before it can be stroked.
+func Stroke(p Path, opts StrokeOptions) Path {
+	if opts.MiterLimit < 1 {
+		opts.MiterLimit = DefaultMiterLimit
+	}
+	if opts.Tolerance <= 0 {
+		opts.Tolerance = DefaultStrokeTolerance
+	}
+	s := &stroker{opts: opts, distance: opts.Width / 2, res: Path{}}
+	if s.distance <= 0 {
+		return s.res
+	}
+	for _, subpath := range p.Subpaths() {
+		segments := nonDegenerateSegments(subpath.Segments)
+		if len(segments) == 0 {
+			s.pointCaps(subpath.Segments[0].From())
+			continue
+		}
+		reversed := make([]PathSegment, len(segments))
+		for i, segment := range segments {
+			reversed[len(segments)-1-i] = reverseSegment(segment)
+		}
+		if subpath.Closed {
+			s.moveTo(s.offset(segments[0], 0))
+			s.side(segments, true)
+			s.close()
+			s.moveTo(s.offset(reversed[0], 0))
+			s.side(reversed, true)
+			s.close()
+		} else {
+			s.moveTo(s.offset(segments[0], 0))
+			s.side(segments, false)
+			s.capEnd(segments[len(segments)-1])
+			s.side(reversed, false)
+			s.capEnd(reversed[len(reversed)-1])
+			s.close()
+		}
+	}
+	return s.res
+}
+
+type stroker struct {
+	opts StrokeOptions
+
+	// distance is how far the edge being drawn is from the path, in the
+	// direction of the segments' Normals.
+	distance float64
+
+	// offsetting is set when the stroker offsets a path rather than outlining
+	// a stroke, so that inner corners are not filled.
+	offsetting bool
+
+	res Path
+}
+
+func (s *stroker) moveTo(p Point) {
+	s.res = append(s.res, PathCmd{"M", []float64{p.X, p.Y}})
+}
+
+func (s *stroker) lineTo(p Point) {
+	s.res = append(s.res, PathCmd{"L", []float64{p.X, p.Y}})
+}
+
+func (s *stroker) arcTo(radius float64, largeArc, sweep bool, p Point) {
+	s.res = append(s.res, PathCmd{"A", []float64{radius, radius, 0, boolFlag(largeArc),
+		boolFlag(sweep), p.X, p.Y}})
+}
+
+func (s *stroker) close() {
+	s.res = append(s.res, PathCmd{"Z", []float64{}})
+}
+
+// offset finds the point on the edge of the stroke beside a segment, in the
+// direction of its Normal.
+func (s *stroker) offset(segment PathSegment, t float64) Point {
+	return offsetPoint(segment.Evaluate(t), Normal(segment, t), s.distance)
+}
+
+// side draws the edge of the stroke along a subpath, from the current point
+// beside its start. The edge of a closed subpath goes all the way around,
+// including the corner at its start.
+func (s *stroker) side(segments []PathSegment, closed bool) {
+	for i, segment := range segments {
+		s.offsetSegment(segment)
+		if i+1 < len(segments) {
+			s.join(segment, segments[i+1])
+		} else if closed {
+			s.join(segment, segments[0])
+		}
+	}
+}
+
+// offsetSegment draws the edge of the stroke beside a segment.
+func (s *stroker) offsetSegment(segment PathSegment) {
+	for _, piece := range segmentOffset(segment, s.distance, s.opts.Tolerance) {
+		s.res = append(s.res, segmentCommand(piece))
+	}
+}
+
+// join draws a corner of the stroke from the end of one segment to the start
+// of the next.
+func (s *stroker) join(before, after PathSegment) {
+	vertex := before.To()
+	t1, t2 := Tangent(before, 1), Tangent(after, 0)
+	n1, n2 := Point{-t1.Y, t1.X}, Point{-t2.Y, t2.X}
+	start := offsetPoint(vertex, n1, s.distance)
+	end := offsetPoint(vertex, n2, s.distance)
+
+	turn := cross(t1, t2)
+	if math.Abs(turn) < 1e-9 && dot(t1, t2) > 0 {
+		s.lineTo(end)
+		return
+	} else if turn*s.distance > 0 {
+		// The path turns towards this side, so the corner is inside. Going
+		// through the vertex keeps the overlap of the two pieces filled.
+		if !s.offsetting {
+			s.lineTo(vertex)
+		}
+		s.lineTo(end)
+		return
+	}
+
+	switch s.opts.Join {
+	case RoundJoin:
+		s.arcTo(math.Abs(s.distance), false, s.distance < 0, end)
+	case BevelJoin:
+		s.lineTo(end)
+	case MiterJoin:
+		s.miterJoin(vertex, n1, n2, end)
+	case ArcsJoin:
+		if !s.arcsJoin(before, after, start, end) {
+			s.miterJoin(vertex, n1, n2, end)
+		}
+	}
+}
+
+func (s *stroker) miterJoin(vertex, n1, n2, end Point) {
+	// The miter is 1/cos(theta/2) times as long as half the stroke is wide,
+	// where theta is the angle between the normals.
+	cosHalfAngle := math.Sqrt(math.Max(0, (1+dot(n1, n2))/2))
+	if cosHalfAngle*s.opts.MiterLimit >= 1 {
+		scale := s.distance / (2 * cosHalfAngle * cosHalfAngle)
+		s.lineTo(Point{vertex.X + scale*(n1.X+n2.X), vertex.Y + scale*(n1.Y+n2.Y)})
+	}
+	s.lineTo(end)
+}
+
+// arcsJoin draws an arcs join, if the edges of the stroke can be extended to
+// meet each other.
+func (s *stroker) arcsJoin(before, after PathSegment, start, end Point) bool {
+	vertex := before.To()
+	t1, t2 := Tangent(before, 1), Tangent(after, 0)
+	edge1 := s.edgeExtension(Curvature(before, 1), vertex, t1)
+	edge2 := s.edgeExtension(Curvature(after, 0), vertex, t2)
+	if edge1 == nil || edge2 == nil {
+		return false
+	}
+
+	// The edges must meet ahead of the first one and behind the second.
+	var meet *Point
+	for _, p := range edge1.intersections(edge2) {
+		p := p
+		if dot(Point{p.X - start.X, p.Y - start.Y}, t1) <= 0 ||
+			dot(Point{end.X - p.X, end.Y - p.Y}, t2) <= 0 {
+			continue
+		}
+		if meet == nil || (Line{vertex, p}).Length() < (Line{vertex, *meet}).Length() {
+			meet = &p
+		}
+	}
+	if meet == nil || (Line{vertex, *meet}).Length() == 0 {
+		return false
+	}
+	if (Line{vertex, *meet}).Length() > s.opts.MiterLimit*math.Abs(s.distance) {
+		s.lineTo(end)
+		return true
+	}
+	edge1.drawTo(s, *meet)
+	edge2.drawTo(s, end)
+	return true
+}
+
+// edgeExtension finds the circle or line which continues the edge of the
+// stroke beside a vertex, given the curvature and tangent of the path there.
+// It returns nil if the stroke is too wide for the curvature.
+func (s *stroker) edgeExtension(curvature float64, vertex, tangent Point) *strokeEdge {
+	normal := Point{-tangent.Y, tangent.X}
+	point := offsetPoint(vertex, normal, s.distance)
+	if math.IsNaN(curvature) || math.IsInf(curvature, 0) {
+		return nil
+	} else if math.Abs(curvature*s.distance) < 1e-9 {
+		return &strokeEdge{point: point, direction: tangent}
+	} else if 1-curvature*s.distance <= 0 {
+		return nil
+	}
+	center := offsetPoint(vertex, normal, 1/curvature)
+	return &strokeEdge{point: point, direction: tangent, center: center,
+		radius: math.Abs(1/curvature - s.distance), sweep: 1/curvature > s.distance}
+}
+
+// capEnd draws the cap at the end of a subpath, from one side of the stroke
+// to the other.
+func (s *stroker) capEnd(last PathSegment) {
+	end := last.To()
+	t := Tangent(last, 1)
+	n := Point{-t.Y, t.X}
+	h := s.distance
+	switch s.opts.Cap {
+	case ButtCap:
+		s.lineTo(offsetPoint(end, n, -h))
+	case RoundCap:
+		s.arcTo(h, false, false, offsetPoint(end, n, -h))
+	case SquareCap:
+		s.lineTo(Point{end.X + h*(n.X+t.X), end.Y + h*(n.Y+t.Y)})
+		s.lineTo(Point{end.X + h*(t.X-n.X), end.Y + h*(t.Y-n.Y)})
+		s.lineTo(offsetPoint(end, n, -h))
+	}
+}
+
+// pointCaps draws the caps of a subpath with no length.
+func (s *stroker) pointCaps(p Point) {
+	h := s.distance
+	switch s.opts.Cap {
+	case RoundCap:
+		s.moveTo(Point{p.X + h, p.Y})
+		s.arcTo(h, false, true, Point{p.X - h, p.Y})
+		s.arcTo(h, false, true, Point{p.X + h, p.Y})
+		s.close()
+	case SquareCap:
+		s.moveTo(Point{p.X - h, p.Y - h})
+		s.lineTo(Point{p.X + h, p.Y - h})
+		s.lineTo(Point{p.X + h, p.Y + h})
+		s.lineTo(Point{p.X - h, p.Y + h})
+		s.close()
+	}
+}
+
+// A strokeEdge is a circle or line which extends the edge of a stroke past a
+// corner. Lines have a radius of zero.
+type strokeEdge struct {
+	point     Point
+	direction Point
+	center    Point
+	radius    float64
+	sweep     bool
+}
+
+func (e *strokeEdge) intersections(e1 *strokeEdge) []Point {
+	if e.radius == 0 && e1.radius == 0 {
+		return lineIntersections(e.point, e.direction, e1.point, e1.direction)
+	} else if e.radius == 0 {
+		return circleLineIntersections(e1.center, e1.radius, e.point, e.direction)
+	} else if e1.radius == 0 {
+		return circleLineIntersections(e.center, e.radius, e1.point, e1.direction)
+	}
+	return circleIntersections(e.center, e.radius, e1.center, e1.radius)
+}
+
+// drawTo draws the edge from the current point to p.
+func (e *strokeEdge) drawTo(s *stroker, p Point) {
+	if e.radius == 0 {
+		s.lineTo(p)
+	} else {
+		s.arcTo(e.radius, false, e.sweep, p)
+	}
+}
+
+func lineIntersections(p1, d1, p2, d2 Point) []Point {
+	denominator := cross(d1, d2)
+	if denominator == 0 {
+		return nil
+	}
+	t := cross(Point{p2.X - p1.X, p2.Y - p1.Y}, d2) / denominator
+	return []Point{offsetPoint(p1, d1, t)}
+}
+
+// circleLineIntersections intersects a circle with a line through p with the
+// unit direction d.
+func circleLineIntersections(center Point, radius float64, p, d Point) []Point {
+	toCenter := Point{center.X - p.X, center.Y - p.Y}
+	along := dot(toCenter, d)
+	across := cross(d, toCenter)
+	if math.Abs(across) > radius {
+		return nil
+	}
+	half := math.Sqrt(radius*radius - across*across)
+	return []Point{offsetPoint(p, d, along-half), offsetPoint(p, d, along+half)}
+}
+
+func circleIntersections(c1 Point, r1 float64, c2 Point, r2 float64) []Point {
+	between := Point{c2.X - c1.X, c2.Y - c1.Y}
+	distance := norm(between)
+	if distance == 0 || distance > r1+r2 || distance < math.Abs(r1-r2) {
+		return nil
+	}
+	along := (distance*distance + r1*r1 - r2*r2) / (2 * distance)
+	across := math.Sqrt(math.Max(0, r1*r1-along*along))
+	u := Point{between.X / distance, between.Y / distance}
+	mid := offsetPoint(c1, u, along)
+	normal := Point{-u.Y, u.X}
+	return []Point{offsetPoint(mid, normal, across), offsetPoint(mid, normal, -across)}
+}
+
+func offsetPoint(p, direction Point, distance float64) Point {
+	return Point{p.X + distance*direction.X, p.Y + distance*direction.Y}
+}
+
+func boolFlag(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// nonDegenerateSegments removes the segments which are single points.
+func nonDegenerateSegments(segments []PathSegment) []PathSegment {
+	var res []PathSegment
+	for _, segment := range segments {
+		if !degenerateSegment(segment) {
+			res = append(res, segment)
+		}
+	}
+	return res
+}
+
+// degenerateSegment checks if a segment is a single point, or an arc which is
+// not drawn because it ends where it starts.
+func degenerateSegment(s PathSegment) bool {
+	bounds := s.Bounds()
+	return !(bounds.Width() > 0 || bounds.Height() > 0)
+}
+
+// reverseSegment makes a segment which traces the same points backwards.
+func reverseSegment(s PathSegment) PathSegment {
+	switch s := s.(type) {
+	case Line:
+		return Line{s.End, s.Start}
+	case *QuadraticBezier:
+		return &QuadraticBezier{s.End, s.Control, s.Start}
+	case *CubicBezier:
+		return &CubicBezier{s.End, s.Control2, s.Control1, s.Start}
+	case *ArcParams:
+		return &ArcParams{s.Center, s.EndAngle, s.StartAngle, s.Rotation, s.XRadius,
+			s.YRadius, !s.Sweep}
+	}
+	panic("unsupported segment type")
+}
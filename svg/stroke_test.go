@@ -0,0 +1,104 @@
+package svg
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestStrokeCaps(t *testing.T) {
+	path, _ := ParsePath("M5 10H15")
+	expected := map[LineCap]float64{ButtCap: 20, RoundCap: 20 + math.Pi, SquareCap: 24}
+	for lineCap, area := range expected {
+		outline := Stroke(path, StrokeOptions{Width: 2, Cap: lineCap})
+		if err := outline.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		if actual := strokeArea(outline); math.Abs(actual-area) > 0.05 {
+			t.Error("cap", lineCap, "expected area", area, "but got", actual)
+		}
+	}
+}
+
+func TestStrokeJoins(t *testing.T) {
+	// The square's stroke covers 12*12-8*8 units with sharp corners, and the
+	// round and bevel joins cut part of each corner off.
+	path, _ := ParsePath("M5 5H15V15H5Z")
+	expected := map[LineJoin]float64{
+		MiterJoin: 80,
+		RoundJoin: 80 - 4*(1-math.Pi/4),
+		BevelJoin: 78,
+		ArcsJoin:  80,
+	}
+	for join, area := range expected {
+		outline := Stroke(path, StrokeOptions{Width: 2, Join: join, Cap: RoundCap})
+		if actual := strokeArea(outline); math.Abs(actual-area) > 0.05 {
+			t.Error("join", join, "expected area", area, "but got", actual)
+		}
+	}
+}
+
+func TestStrokeMiterLimit(t *testing.T) {
+	// The miter of a right angle is sqrt(2) times half the width.
+	path, _ := ParsePath("M5 15V5H15")
+	for _, limit := range []float64{1.4, 1.5} {
+		outline := Stroke(path, StrokeOptions{Width: 2, MiterLimit: limit})
+		expected := 39.5
+		if limit > math.Sqrt2 {
+			expected = 40
+		}
+		if actual := strokeArea(outline); math.Abs(actual-expected) > 0.05 {
+			t.Error("limit", limit, "expected area", expected, "but got", actual)
+		}
+	}
+}
+
+func TestStrokeCurves(t *testing.T) {
+	path, _ := NewPathBuilder().Circle(10, 10, 6).Path()
+	outline := Stroke(path, StrokeOptions{Width: 2, Tolerance: 1e-3})
+	if actual, expected := strokeArea(outline), 4*math.Pi*6; math.Abs(actual-expected) > 0.1 {
+		t.Error("expected area", expected, "but got", actual)
+	}
+	for _, subpath := range outline.Flatten(1e-3) {
+		for _, p := range subpath {
+			if d := math.Abs((Line{Point{10, 10}, p}).Length() - 6); math.Abs(d-1) > 1e-3 {
+				t.Fatal("point", p, "is", d, "from the circle")
+			}
+		}
+	}
+
+	// The arcs join of two circular arcs is closer to a miter join than a
+	// round join is.
+	path, _ = ParsePath("M5 15A8 8 0 0 1 10 5A8 8 0 0 1 15 15")
+	areas := map[LineJoin]float64{}
+	for _, join := range []LineJoin{MiterJoin, RoundJoin, ArcsJoin} {
+		areas[join] = strokeArea(Stroke(path, StrokeOptions{Width: 2, Join: join}))
+	}
+	if !(areas[RoundJoin] < areas[ArcsJoin] && areas[ArcsJoin] < areas[MiterJoin]) {
+		t.Error("unexpected areas:", areas)
+	}
+}
+
+func TestStrokeDots(t *testing.T) {
+	path, _ := ParsePath("M5 5ZM15 15L15 15")
+	expected := map[LineCap]float64{ButtCap: 0, RoundCap: 2 * math.Pi, SquareCap: 8}
+	for lineCap, area := range expected {
+		outline := Stroke(path, StrokeOptions{Width: 2, Cap: lineCap})
+		if actual := strokeArea(outline); math.Abs(actual-area) > 0.05 {
+			t.Error("cap", lineCap, "expected area", area, "but got", actual)
+		}
+	}
+}
+
+// strokeArea finds the area of an outline between (0, 0) and (20, 20). It is
+// filled at ten times its size, so that curves are flattened precisely.
+func strokeArea(outline Path) float64 {
+	img := image.NewRGBA(image.Rect(0, 0, 200, 200))
+	FillPath(img, outline.Transform(ScaleMatrix(10, 10)), color.Black, NonZero)
+	var area float64
+	for i := 3; i < len(img.Pix); i += 4 {
+		area += float64(img.Pix[i]) / 255
+	}
+	return area / 100
+}
@@ -0,0 +1,48 @@
+package svg
+
+import "math"
+
+// Tangent computes the unit vector pointing in the direction of a segment at
+// parameter t.
+//
+// Where the derivative vanishes, like at a control point which coincides with
+// an end point or at a cusp, the direction is the limit of the tangent as the
+// parameter approaches t from inside the segment: from above, except at t=1.
+// If the segment is a single point, Tangent returns the zero vector.
+func Tangent(s PathSegment, t float64) Point {
+	d1 := s.Derivative(t)
+	d2 := s.SecondDerivative(t)
+	chord := Point{s.To().X - s.From().X, s.To().Y - s.From().Y}
+
+	// Near a zero of the first derivative, it is proportional to the second
+	// derivative times the (signed) distance from t.
+	scale := math.Hypot(d2.X, d2.Y) + math.Hypot(chord.X, chord.Y)
+	if math.Hypot(d1.X, d1.Y) > 1e-10*scale {
+		return normalize(d1)
+	}
+	if math.Hypot(d2.X, d2.Y) > 1e-10*scale {
+		if t >= 1 {
+			return normalize(Point{-d2.X, -d2.Y})
+		}
+		return normalize(d2)
+	}
+
+	// When both derivatives vanish, the curve leaves t along its chord.
+	if chord.X == 0 && chord.Y == 0 {
+		return Point{}
+	}
+	return normalize(chord)
+}
+
+// Normal computes the unit vector perpendicular to a segment at parameter t.
+// It is the tangent rotated by 90 degrees, which points to the right of the
+// direction of travel when the y axis points down, as it does in SVG.
+func Normal(s PathSegment, t float64) Point {
+	tangent := Tangent(s, t)
+	return Point{-tangent.Y, tangent.X}
+}
+
+func normalize(p Point) Point {
+	length := math.Hypot(p.X, p.Y)
+	return Point{p.X / length, p.Y / length}
+}
@@ -0,0 +1,62 @@
+package svg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDerivatives(t *testing.T) {
+	arc, _ := (&Arc{Point{50, 10}, Point{60, 20}, 10, 20, 30, true, false}).Params()
+	segments := []PathSegment{
+		Line{Point{1, 2}, Point{5, -3}},
+		&QuadraticBezier{Point{10, 10}, Point{40, 40}, Point{20, 50}},
+		&CubicBezier{Point{96, 89}, Point{13, 46}, Point{14, 64}, Point{15, 91}},
+		arc,
+	}
+	const h = 1e-5
+	for i, s := range segments {
+		for _, frac := range []float64{0.1, 0.3, 0.5, 0.9} {
+			p1, p2 := s.Evaluate(frac-h), s.Evaluate(frac+h)
+			expected := Point{(p2.X - p1.X) / (2 * h), (p2.Y - p1.Y) / (2 * h)}
+			if actual := s.Derivative(frac); !approxEqualVector(actual, expected) {
+				t.Error("segment", i, "should have derivative", expected, "but got", actual)
+			}
+			d1, d2 := s.Derivative(frac-h), s.Derivative(frac+h)
+			expected = Point{(d2.X - d1.X) / (2 * h), (d2.Y - d1.Y) / (2 * h)}
+			if actual := s.SecondDerivative(frac); !approxEqualVector(actual, expected) {
+				t.Error("segment", i, "should have second derivative", expected, "but got", actual)
+			}
+		}
+	}
+}
+
+func TestTangent(t *testing.T) {
+	cases := []struct {
+		segment PathSegment
+		t       float64
+		tangent Point
+	}{
+		{Line{Point{0, 0}, Point{3, 4}}, 0.5, Point{0.6, 0.8}},
+		{&CubicBezier{Point{0, 0}, Point{0, 0}, Point{10, 10}, Point{10, 0}}, 0,
+			Point{math.Sqrt2 / 2, math.Sqrt2 / 2}},
+		{&CubicBezier{Point{0, 0}, Point{0, 10}, Point{10, 0}, Point{10, 0}}, 1,
+			Point{math.Sqrt2 / 2, -math.Sqrt2 / 2}},
+		{&CubicBezier{Point{0, 0}, Point{0, 0}, Point{0, 0}, Point{0, 10}}, 0, Point{0, 1}},
+		{&CubicBezier{Point{0, 0}, Point{1, 1}, Point{0, 1}, Point{1, 0}}, 0.5, Point{0, -1}},
+		{&QuadraticBezier{Point{0, 0}, Point{5, 0}, Point{5, 0}}, 1, Point{1, 0}},
+		{Line{Point{1, 1}, Point{1, 1}}, 0, Point{}},
+	}
+	for i, c := range cases {
+		if actual := Tangent(c.segment, c.t); !approxEqualVector(actual, c.tangent) {
+			t.Error("case", i, "should have tangent", c.tangent, "but got", actual)
+		}
+	}
+	if normal := Normal(Line{Point{0, 0}, Point{1, 0}}, 0); !approxEqualVector(normal, Point{0, 1}) {
+		t.Error("unexpected normal:", normal)
+	}
+}
+
+func approxEqualVector(p, p1 Point) bool {
+	scale := math.Max(1, math.Hypot(p1.X, p1.Y))
+	return Line{p, p1}.Length() < 1e-4*scale
+}
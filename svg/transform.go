@@ -0,0 +1,111 @@
+package svg
+
+var transformArgCounts = map[string][]int{
+	"matrix":    {6},
+	"translate": {1, 2},
+	"scale":     {1, 2},
+	"rotate":    {1, 3},
+	"skewX":     {1},
+	"skewY":     {1},
+}
+
+// ParseTransform parses a transform list, like the value of the "transform"
+// attribute, into a single matrix. Functions are applied from right to left,
+// so "translate(10) scale(2)" scales before it translates.
+//
+// If the list is invalid, the returned error is a *ParseError.
+func ParseTransform(s string) (Matrix, error) {
+	scanner := &pathScanner{data: s}
+	res := IdentityMatrix()
+
+	scanner.skipSpace()
+	for !scanner.done() {
+		m, err := scanner.transformFunction()
+		if err != nil {
+			return Matrix{}, err
+		}
+		res = res.Multiply(m)
+		scanner.command++
+		if comma := scanner.skipCommaSpace(); comma >= 0 && scanner.done() {
+			return Matrix{}, scanner.errorAt(comma, comma+1, ReasonUnexpectedComma)
+		}
+	}
+
+	return res, nil
+}
+
+// transformFunction reads a function like "rotate(30 5 5)" and its arguments.
+func (s *pathScanner) transformFunction() (Matrix, *ParseError) {
+	start := s.pos
+	for c := s.peek(); (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'); c = s.peek() {
+		s.pos++
+	}
+	name := s.data[start:s.pos]
+	counts, ok := transformArgCounts[name]
+	if !ok {
+		if name == "" {
+			return Matrix{}, s.errorAt(start, start, ReasonUnexpectedCharacter)
+		}
+		return Matrix{}, s.errorAt(start, s.pos, ReasonUnknownTransform)
+	}
+	s.skipSpace()
+	if s.peek() != '(' {
+		return Matrix{}, s.errorAt(s.pos, s.pos, ReasonUnexpectedCharacter)
+	}
+	s.pos++
+	s.skipSpace()
+
+	var args []float64
+	for s.peek() != ')' {
+		if len(args) > 0 {
+			if comma := s.skipCommaSpace(); comma >= 0 && s.peek() == ')' {
+				return Matrix{}, s.errorAt(comma, comma+1, ReasonUnexpectedComma)
+			}
+		}
+		if !s.startsNumber() {
+			return Matrix{}, s.errorAt(s.pos, s.pos, ReasonUnexpectedCharacter)
+		}
+		num, err := s.number()
+		if err != nil {
+			return Matrix{}, err
+		}
+		args = append(args, num)
+		s.skipSpace()
+	}
+	s.pos++
+
+	for _, count := range counts {
+		if len(args) == count {
+			return transformMatrix(name, args), nil
+		}
+	}
+	return Matrix{}, s.errorAt(start, s.pos, ReasonInvalidArgumentCount)
+}
+
+func transformMatrix(name string, args []float64) Matrix {
+	switch name {
+	case "matrix":
+		return Matrix{args[0], args[1], args[2], args[3], args[4], args[5]}
+	case "translate":
+		if len(args) == 1 {
+			return TranslateMatrix(args[0], 0)
+		}
+		return TranslateMatrix(args[0], args[1])
+	case "scale":
+		if len(args) == 1 {
+			return ScaleMatrix(args[0], args[0])
+		}
+		return ScaleMatrix(args[0], args[1])
+	case "rotate":
+		if len(args) == 1 {
+			return RotateMatrix(args[0])
+		}
+		center := TranslateMatrix(args[1], args[2])
+		return center.Multiply(RotateMatrix(args[0])).Multiply(TranslateMatrix(-args[1], -args[2]))
+	case "skewX":
+		return SkewXMatrix(args[0])
+	case "skewY":
+		return SkewYMatrix(args[0])
+	}
+	panic("unknown transform: " + name)
+}
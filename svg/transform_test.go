@@ -0,0 +1,48 @@
+package svg
+
+import "testing"
+
+func TestParseTransform(t *testing.T) {
+	cases := map[string]Matrix{
+		"":                                 IdentityMatrix(),
+		"matrix(1 2 3 4 5 6)":              {1, 2, 3, 4, 5, 6},
+		" translate(10)":                   TranslateMatrix(10, 0),
+		"translate(10,-5e1) scale(2)":      {2, 0, 0, 2, 10, -50},
+		"scale(2 3),rotate(90)":            {0, 3, -2, 0, 0, 0},
+		"rotate(90 10 10)":                 {0, 1, -1, 0, 20, 0},
+		"skewX(45)skewY(45)":               {2, 1, 1, 1, 0, 0},
+		"translate ( 1 , 2 ) ,\n scale(1)": TranslateMatrix(1, 2),
+	}
+	for str, expected := range cases {
+		actual, err := ParseTransform(str)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %s", str, err)
+		} else if !actual.approxEqual(expected) {
+			t.Errorf("expected %v for %q but got %v", expected, str, actual)
+		}
+	}
+
+	errorCases := []struct {
+		str    string
+		offset int
+		reason ParseErrorReason
+	}{
+		{"translate(1) rotate(1 2)", 13, ReasonInvalidArgumentCount},
+		{"scale(1) spin(3)", 9, ReasonUnknownTransform},
+		{"scale(1,)", 7, ReasonUnexpectedComma},
+		{"scale(1),", 8, ReasonUnexpectedComma},
+		{"scale 2", 6, ReasonUnexpectedCharacter},
+		{"scale(1 x)", 8, ReasonUnexpectedCharacter},
+		{"scale(1", 7, ReasonUnexpectedCharacter},
+		{"scale(-)", 6, ReasonInvalidNumber},
+	}
+	for _, c := range errorCases {
+		_, err := ParseTransform(c.str)
+		if parseErr, ok := err.(*ParseError); !ok {
+			t.Errorf("expected ParseError for %q but got %v", c.str, err)
+		} else if parseErr.Offset != c.offset || parseErr.Reason != c.reason {
+			t.Errorf("expected %s at %d for %q but got %s at %d", c.reason, c.offset, c.str,
+				parseErr.Reason, parseErr.Offset)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// Package svgdoc reads SVG documents and extracts their shapes as paths.
+package svgdoc
+
+import (
+	"encoding/xml"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/unixpickle/svgdemos/svg"
+)
+
+const svgNamespace = "http://www.w3.org/2000/svg"
+
+// These elements and their children are never rendered directly.
+var hiddenElements = map[string]bool{"defs": true, "symbol": true, "clipPath": true,
+	"mask": true, "pattern": true, "marker": true, "linearGradient": true,
+	"radialGradient": true, "title": true, "desc": true, "metadata": true}
+
+// A Shape is a drawable element from an SVG document.
+type Shape struct {
+	// Element is the tag name of the element, like "rect" or "path".
+	Element string
+
+	// ID is the element's id attribute, or "" if it has none.
+	ID string
+
+	// Attributes maps each of the element's attribute names (without any
+	// namespace prefix) to its value.
+	Attributes map[string]string
+
+	// Transform is the transform list of the element's ancestors followed by
+	// its own transform attribute. It is "" if no transforms apply.
+	Transform string
+
+	// Path is the element's geometry in its own coordinate system, that is,
+	// without Transform applied.
+	Path svg.Path
+}
+
+// TransformedPath returns the shape's path with its transform applied. If no
+// transform applies, the path is returned as-is.
+func (s *Shape) TransformedPath() (svg.Path, error) {
+	if s.Transform == "" {
+		return s.Path, nil
+	}
+	m, err := svg.ParseTransform(s.Transform)
+	if err != nil {
+		return nil, err
+	}
+	return s.Path.Transform(m), nil
+}
+
+// An ElementError indicates that an element's geometry could not be read.
+type ElementError struct {
+	Element string
+	ID      string
+	Err     error
+}
+
+func (e *ElementError) Error() string {
+	if e.ID != "" {
+		return "<" + e.Element + " id=\"" + e.ID + "\">: " + e.Err.Error()
+	}
+	return "<" + e.Element + ">: " + e.Err.Error()
+}
+
+// ElementErrors lists the elements of a document whose geometry could not be
+// read.
+type ElementErrors []*ElementError
+
+func (e ElementErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ParseFile reads the SVG document at a path and returns its shapes.
+func ParseFile(path string) ([]*Shape, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse reads an SVG document and returns its shapes in document order.
+//
+// Every <path>, <rect>, <circle>, <ellipse>, <line>, <polyline> and <polygon>
+// is returned, including those nested in groups. Elements inside <defs> and
+// other containers which are not rendered directly are skipped, as are
+// shapes which would not be rendered, like a <rect> with a width of 0. Other
+// elements, including <use>, are ignored. Path data is read up to its first
+// error, the way a browser renders it.
+//
+// Shapes whose other attributes cannot be read are skipped. In that case, the
+// remaining shapes are returned along with an ElementErrors listing the
+// skipped elements. If the document itself cannot be read, no shapes are
+// returned.
+func Parse(r io.Reader) ([]*Shape, error) {
+	decoder := xml.NewDecoder(r)
+	var shapes []*Shape
+	var errs ElementErrors
+
+	// There is one transform and viewport entry for each open element. The
+	// hidden counter tracks how many open elements are not rendered.
+	var transforms []string
+	var viewports []viewport
+	hidden := 0
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			attrs := attributeMap(token.Attr)
+			transform := attrs["transform"]
+			if len(transforms) > 0 {
+				transform = joinTransforms(transforms[len(transforms)-1], transform)
+			}
+			transforms = append(transforms, transform)
+
+			var vp viewport
+			if len(viewports) > 0 {
+				vp = viewports[len(viewports)-1]
+			}
+			if token.Name.Local == "svg" {
+				vp = newViewport(attrs, vp)
+			}
+			viewports = append(viewports, vp)
+
+			isSVG := token.Name.Space == svgNamespace || token.Name.Space == ""
+			if hidden > 0 || !isSVG || hiddenElements[token.Name.Local] {
+				hidden++
+				continue
+			}
+			path, err := shapePath(token.Name.Local, attrs, vp)
+			if err != nil {
+				errs = append(errs, &ElementError{token.Name.Local, attrs["id"], err})
+			} else if len(path) > 0 {
+				shapes = append(shapes, &Shape{
+					Element:    token.Name.Local,
+					ID:         attrs["id"],
+					Attributes: attrs,
+					Transform:  transform,
+					Path:       path,
+				})
+			}
+		case xml.EndElement:
+			transforms = transforms[:len(transforms)-1]
+			viewports = viewports[:len(viewports)-1]
+			if hidden > 0 {
+				hidden--
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return shapes, errs
+	}
+	return shapes, nil
+}
+
+func attributeMap(attrs []xml.Attr) map[string]string {
+	res := map[string]string{}
+	for _, attr := range attrs {
+		res[attr.Name.Local] = attr.Value
+	}
+	return res
+}
+
+func joinTransforms(outer, inner string) string {
+	outer = strings.TrimSpace(outer)
+	inner = strings.TrimSpace(inner)
+	if outer == "" {
+		return inner
+	} else if inner == "" {
+		return outer
+	}
+	return outer + " " + inner
+}
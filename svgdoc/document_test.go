@@ -0,0 +1,187 @@
+package svgdoc
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/unixpickle/svgdemos/svg"
+)
+
+const testDocument = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<svg version="1.1" viewBox="0 0 100 100" xmlns="http://www.w3.org/2000/svg"
+     xmlns:xlink="http://www.w3.org/1999/xlink">
+  <defs>
+    <path id="hidden" d="M0,0 L10,10" />
+  </defs>
+  <rect id="sky" x="0" y="0" width="100" height="50px" fill="blue" />
+  <g transform="translate(10, 20)">
+    <g transform="scale(2)">
+      <circle id="sun" cx="5" cy="5" r="2" transform="rotate(5)" />
+    </g>
+    <rect x="1" y="2" width="4" height="6" rx="1" />
+    <rect width="0" height="10" />
+  </g>
+  <line x1="1" y1="2" x2="3" y2="4" />
+  <polyline points="1,2 3,4 5" />
+  <polygon points="1,2,3,4-5-6" />
+  <ellipse cx="1" cy="2" rx="3" ry="4" />
+  <path d="M1 2h3" />
+</svg>`
+
+func TestParse(t *testing.T) {
+	shapes, err := Parse(strings.NewReader(testDocument))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Shape{
+		{Element: "rect", ID: "sky", Path: mustParsePath("M0 0H100V50H0Z")},
+		{Element: "circle", ID: "sun", Transform: "translate(10, 20) scale(2) rotate(5)",
+			Path: mustParsePath("M7 5A2 2 0 0 1 5 7A2 2 0 0 1 3 5A2 2 0 0 1 5 3A2 2 0 0 1 7 5Z")},
+		{Element: "rect", Transform: "translate(10, 20)", Path: mustParsePath("M2 2H4" +
+			"A1 1 0 0 1 5 3V7A1 1 0 0 1 4 8H2A1 1 0 0 1 1 7V3A1 1 0 0 1 2 2Z")},
+		{Element: "line", Path: mustParsePath("M1 2L3 4")},
+		{Element: "polyline", Path: mustParsePath("M1 2L3 4")},
+		{Element: "polygon", Path: mustParsePath("M1 2L3 4-5-6Z")},
+		{Element: "ellipse", Path: mustParsePath("M4 2A3 4 0 0 1 1 6A3 4 0 0 1-2 2" +
+			"A3 4 0 0 1 1-2A3 4 0 0 1 4 2Z")},
+		{Element: "path", Path: mustParsePath("M1 2h3")},
+	}
+	if len(shapes) != len(expected) {
+		t.Fatal("expected", len(expected), "shapes but got", len(shapes))
+	}
+	for i, e := range expected {
+		a := shapes[i]
+		if a.Element != e.Element || a.ID != e.ID || a.Transform != e.Transform {
+			t.Errorf("shape %d should be <%s id=%q transform=%q> but is <%s id=%q transform=%q>",
+				i, e.Element, e.ID, e.Transform, a.Element, a.ID, a.Transform)
+		}
+		if a.Path.String() != e.Path.String() {
+			t.Errorf("shape %d should have path %s but has %s", i, e.Path, a.Path)
+		}
+	}
+	if shapes[0].Attributes["fill"] != "blue" {
+		t.Error("unexpected attributes:", shapes[0].Attributes)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	doc := `<svg>
+  <rect id="good" width="10" height="10" />
+  <rect id="em" width="10em" height="10" />
+  <rect id="percent" width="10%" height="10" />
+  <polygon id="points" points="1 2 x" />
+  <circle id="last" r="1" />
+</svg>`
+	shapes, err := Parse(strings.NewReader(doc))
+	errs, ok := err.(ElementErrors)
+	if !ok {
+		t.Fatal("expected ElementErrors but got", err)
+	}
+	var ids []string
+	for _, e := range errs {
+		ids = append(ids, e.ID)
+	}
+	if strings.Join(ids, " ") != "em percent points" {
+		t.Error("unexpected element errors:", err)
+	}
+	if len(shapes) != 2 || shapes[0].ID != "good" || shapes[1].ID != "last" {
+		t.Error("the readable shapes should be kept but got", shapes)
+	}
+
+	if shapes, err := Parse(strings.NewReader(`<svg><g></svg>`)); err == nil {
+		t.Error("expected error for invalid XML")
+	} else if _, ok := err.(ElementErrors); ok || shapes != nil {
+		t.Error("invalid XML should give no shapes but got", shapes, err)
+	}
+}
+
+func TestParsePercentages(t *testing.T) {
+	docs := []string{
+		`<svg viewBox="0 0 200 100"><rect width="100%" height="50%" x="10%" /></svg>`,
+		`<svg width="200" height="100"><rect width="100%" height="50%" x="10%" /></svg>`,
+		`<svg width="400" height="200"><svg viewBox="0 0 200 100">
+			<rect width="100%" height="50%" x="10%" /></svg></svg>`,
+	}
+	for _, doc := range docs {
+		shapes, err := Parse(strings.NewReader(doc))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(shapes) != 1 || shapes[0].Path.String() != "M20 0H220V50H20Z" {
+			t.Error("unexpected shapes for document:", doc, shapes)
+		}
+	}
+
+	shapes, err := Parse(strings.NewReader(`<svg viewBox="0 0 30 40"><circle r="10%" /></svg>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The path of a circle starts at its rightmost point.
+	if len(shapes) != 1 || math.Abs(shapes[0].Path[0].Args[0]-math.Sqrt(1250)/10) > 1e-9 {
+		t.Error("unexpected circle:", shapes)
+	}
+}
+
+func TestParseBrokenPath(t *testing.T) {
+	doc := `<svg>
+  <path id="good" d="M1 2h3" />
+  <path id="broken" d="M1 2 L3 4 L5" />
+  <path id="invalid" d="L1 2" />
+  <path id="last" d="M5 6v7" />
+</svg>`
+	shapes, err := Parse(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{"good": "M1 2h3", "broken": "M1 2L3 4", "last": "M5 6v7"}
+	if len(shapes) != len(expected) {
+		t.Fatal("expected", len(expected), "shapes but got", len(shapes))
+	}
+	for _, shape := range shapes {
+		if e := mustParsePath(expected[shape.ID]); shape.Path.String() != e.String() {
+			t.Errorf("shape %q should have path %s but has %s", shape.ID, e, shape.Path)
+		}
+	}
+}
+
+func TestParseUnits(t *testing.T) {
+	doc := `<svg><line x1="1in" y1="2.54cm" x2="25.4mm" y2="72pt" />
+  <rect x="1pc" y="3px" width="4" height=" 5 " /></svg>`
+	shapes, err := Parse(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"M96 96L96 96", "M16 3H20V8H16Z"}
+	if len(shapes) != len(expected) {
+		t.Fatal("expected", len(expected), "shapes but got", len(shapes))
+	}
+	for i, shape := range shapes {
+		if e := mustParsePath(expected[i]); shape.Path.String() != e.String() {
+			t.Errorf("shape %d should have path %s but has %s", i, e, shape.Path)
+		}
+	}
+}
+
+func mustParsePath(s string) svg.Path {
+	path, err := svg.ParsePath(s)
+	if err != nil {
+		panic(err)
+	}
+	return path
+}
+
+func TestTransformedPath(t *testing.T) {
+	shape := &Shape{Transform: "translate(10, 20) scale(2)", Path: mustParsePath("M1 1h2")}
+	path, err := shape.TransformedPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path.String() != "M12 22L16 22" {
+		t.Error("unexpected transformed path:", path)
+	}
+	shape.Transform = "scale(2) translate"
+	if _, err := shape.TransformedPath(); err == nil {
+		t.Error("expected error for invalid transform")
+	}
+}
@@ -0,0 +1,166 @@
+package svgdoc
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/unixpickle/svgdemos/svg"
+)
+
+// A viewport is the size of the nearest <svg> element's coordinate system,
+// which percentages are relative to. Its fields are 0 if the size is unknown.
+type viewport struct {
+	width  float64
+	height float64
+}
+
+// newViewport finds the viewport established by an <svg> element, whose own
+// width and height may be relative to the viewport of its parent.
+func newViewport(attrs map[string]string, parent viewport) viewport {
+	if box, err := svg.ParseNumberList(attrs["viewBox"]); err == nil && len(box) == 4 {
+		return viewport{box[2], box[3]}
+	}
+	size, err := lengths(attrs, parent, "width", "height")
+	if err != nil {
+		return viewport{}
+	}
+	return viewport{size[0], size[1]}
+}
+
+// shapePath converts a shape element into a path. It returns nil if the
+// element is not a shape or if the shape would not be rendered.
+func shapePath(element string, attrs map[string]string, vp viewport) (svg.Path, error) {
+	switch element {
+	case "path":
+		// Like a browser, render the path up to its first error.
+		path, _ := svg.ParsePathLenient(attrs["d"])
+		return path, nil
+	case "rect":
+		return rectPath(attrs, vp)
+	case "circle":
+		return ellipsePath(attrs, vp, "r", "r")
+	case "ellipse":
+		return ellipsePath(attrs, vp, "rx", "ry")
+	case "line":
+		nums, err := lengths(attrs, vp, "x1", "y1", "x2", "y2")
+		if err != nil {
+			return nil, err
+		}
+		return svg.Path{{Name: "M", Args: nums[:2]}, {Name: "L", Args: nums[2:]}}, nil
+	case "polyline", "polygon":
+		return polyPath(attrs, element == "polygon")
+	}
+	return nil, nil
+}
+
+func rectPath(attrs map[string]string, vp viewport) (svg.Path, error) {
+	nums, err := lengths(attrs, vp, "x", "y", "width", "height", "rx", "ry")
+	if err != nil {
+		return nil, err
+	}
+	x, y, width, height, rx, ry := nums[0], nums[1], nums[2], nums[3], nums[4], nums[5]
+	if width <= 0 || height <= 0 {
+		return nil, nil
+	}
+
+	// An unspecified radius takes the value of the other one.
+	if _, ok := attrs["rx"]; !ok {
+		rx = ry
+	} else if _, ok := attrs["ry"]; !ok {
+		ry = rx
+	}
+	return svg.NewPathBuilder().RoundedRect(x, y, width, height, math.Max(rx, 0),
+		math.Max(ry, 0)).Path()
+}
+
+func ellipsePath(attrs map[string]string, vp viewport, rxName,
+	ryName string) (svg.Path, error) {
+	nums, err := lengths(attrs, vp, "cx", "cy", rxName, ryName)
+	if err != nil {
+		return nil, err
+	}
+	cx, cy, rx, ry := nums[0], nums[1], nums[2], nums[3]
+	if rx <= 0 || ry <= 0 {
+		return nil, nil
+	}
+	return svg.NewPathBuilder().Ellipse(cx, cy, rx, ry).Path()
+}
+
+func polyPath(attrs map[string]string, closed bool) (svg.Path, error) {
+	points, err := svg.ParseNumberList(attrs["points"])
+	if err != nil {
+		return nil, err
+	}
+
+	// Like a browser, ignore a trailing coordinate without a partner.
+	points = points[:len(points)-len(points)%2]
+	if len(points) == 0 {
+		return nil, nil
+	}
+
+	path := svg.Path{{Name: "M", Args: points[:2]}}
+	if len(points) > 2 {
+		path = append(path, svg.PathCmd{Name: "L", Args: points[2:]})
+	}
+	if closed {
+		path = append(path, svg.PathCmd{Name: "Z", Args: []float64{}})
+	}
+	return path, nil
+}
+
+// unitSizes maps the absolute units which lengths may use to their sizes in
+// user units, with 96 user units per inch.
+var unitSizes = map[string]float64{"px": 1, "in": 96, "cm": 96 / 2.54, "mm": 96 / 25.4,
+	"pt": 96.0 / 72, "pc": 16}
+
+// lengths reads attributes which contain lengths. Missing attributes have a
+// value of 0. Lengths may be unitless, use an absolute unit, or be percentages
+// of the viewport. Font-relative units like em are not supported.
+func lengths(attrs map[string]string, vp viewport, names ...string) ([]float64, error) {
+	res := make([]float64, len(names))
+	for i, name := range names {
+		value, ok := attrs[name]
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		scale := 1.0
+		if strings.HasSuffix(value, "%") {
+			value = strings.TrimSuffix(value, "%")
+			scale = percentBase(name, vp) / 100
+			if scale == 0 {
+				return nil, errors.New("percentage without a viewport in " + name +
+					" attribute: " + attrs[name])
+			}
+		} else {
+			for unit, size := range unitSizes {
+				if strings.HasSuffix(value, unit) {
+					value = strings.TrimSuffix(value, unit)
+					scale = size
+					break
+				}
+			}
+		}
+		num, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, errors.New("invalid " + name + " attribute: " + attrs[name])
+		}
+		res[i] = num * scale
+	}
+	return res, nil
+}
+
+// percentBase finds the length which a percentage in an attribute is relative
+// to. Horizontal lengths use the viewport's width, vertical lengths use its
+// height, and other lengths use its normalized diagonal.
+func percentBase(name string, vp viewport) float64 {
+	switch name {
+	case "x", "cx", "rx", "x1", "x2", "width":
+		return vp.width
+	case "y", "cy", "ry", "y1", "y2", "height":
+		return vp.height
+	}
+	return math.Sqrt((vp.width*vp.width + vp.height*vp.height) / 2)
+}
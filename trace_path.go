@@ -13,7 +13,9 @@ import (
 const (
 	Size   = 400
 	Border = 1
-	PathStep = 0.01
+
+	// FlattenTolerance is the maximum error of the drawing, in pixels.
+	FlattenTolerance = 0.25
 )
 
 func main() {
@@ -22,7 +24,7 @@ func main() {
 }
 
 func setupEverything() {
-	segments, bounds, err := readPathAndBounds()
+	subpaths, bounds, err := readPathAndBounds()
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
@@ -39,12 +41,12 @@ func setupEverything() {
 	})
 
 	c.SetDrawHandler(func(ctx gogui.DrawContext) {
-		tracePath(ctx, segments, bounds)
+		tracePath(ctx, subpaths, bounds)
 	})
 	c.NeedsUpdate()
 }
 
-func tracePath(ctx gogui.DrawContext, segments []svg.PathSegment, bounds svg.Rect) {
+func tracePath(ctx gogui.DrawContext, subpaths []svg.Subpath, bounds svg.Rect) {
 	var scale float64
 	var translateX, translateY float64
 	if bounds.Width() > bounds.Height() {
@@ -59,41 +61,44 @@ func tracePath(ctx gogui.DrawContext, segments []svg.PathSegment, bounds svg.Rec
 	translateX -= bounds.Min.X * scale
 	translateY -= bounds.Min.Y * scale
 	ctx.SetStroke(gogui.Color{0, 0, 0, 1})
-	for i, segment := range segments {
-		if i == 0 {
-			startPoint := segment.From()
-			ctx.MoveTo(startPoint.X*scale+translateX, startPoint.Y*scale+translateY)
-		}
-		for t := PathStep; t < 1; t += PathStep {
-			point := segment.Evaluate(t)
-			ctx.LineTo(point.X*scale+translateX, point.Y*scale+translateY)
+	for _, subpath := range subpaths {
+		for i, segment := range subpath.Segments {
+			points := segment.Flatten(FlattenTolerance / scale)
+			if i == 0 {
+				ctx.MoveTo(points[0].X*scale+translateX, points[0].Y*scale+translateY)
+			}
+			for _, point := range points[1:] {
+				ctx.LineTo(point.X*scale+translateX, point.Y*scale+translateY)
+			}
 		}
 	}
 	ctx.StrokePath()
 }
 
-func readPathAndBounds() ([]svg.PathSegment, svg.Rect, error) {
+func readPathAndBounds() ([]svg.Subpath, svg.Rect, error) {
 	fmt.Println("Enter path data, then deliver an EOF:")
 	data, err := ioutil.ReadAll(os.Stdin)
 	if err != nil {
 		return nil, svg.Rect{}, err
 	}
-	path, err := svg.ParsePath(string(data))
-	if err != nil {
-		return nil, svg.Rect{}, err
+	path, errs := svg.ParsePathLenient(string(data))
+	for _, err := range errs {
+		fmt.Fprintln(os.Stderr, "Path error:", err)
 	}
 
-	segments := path.Segments()
+	subpaths := path.Subpaths()
 
-	if len(segments) == 0 {
-		return segments, svg.Rect{}, nil
+	if len(subpaths) == 0 {
+		return subpaths, svg.Rect{}, nil
 	}
 
-	bounds := segments[0].Bounds()
-	for _, segment := range segments {
-		b := segment.Bounds()
-		bounds.Min = svg.Point{math.Min(bounds.Min.X, b.Min.X), math.Min(bounds.Min.Y, b.Min.Y)}
-		bounds.Max = svg.Point{math.Max(bounds.Max.X, b.Max.X), math.Max(bounds.Max.Y, b.Max.Y)}
+	bounds := subpaths[0].Segments[0].Bounds()
+	for _, subpath := range subpaths {
+		for _, segment := range subpath.Segments {
+			b := segment.Bounds()
+			bounds.Min = svg.Point{math.Min(bounds.Min.X, b.Min.X), math.Min(bounds.Min.Y, b.Min.Y)}
+			bounds.Max = svg.Point{math.Max(bounds.Max.X, b.Max.X), math.Max(bounds.Max.Y, b.Max.Y)}
+		}
 	}
-	return segments, bounds, nil
+	return subpaths, bounds, nil
 }